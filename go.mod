@@ -1,11 +1,15 @@
 module github.com/aggronmagi/gogen
 
-go 1.15
+go 1.22.0
 
 require (
-	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/cobra v1.6.1
 	github.com/spf13/pflag v1.0.5
-	golang.org/x/sys v0.8.0 // indirect
-	golang.org/x/tools v0.6.0
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
 )
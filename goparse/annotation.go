@@ -0,0 +1,36 @@
+package goparse
+
+import (
+	"strings"
+)
+
+// AnnotationPrefix comment annotation prefix. eg: `// gogen:deprecated=message`
+const AnnotationPrefix = "gogen:"
+
+// ParseAnnotations split `gogen:key=value` annotation lines out of comment text.
+// return the rest comment text and annotations.
+func ParseAnnotations(text string) (rest string, annotations map[string]string) {
+	if !strings.Contains(text, AnnotationPrefix) {
+		return text, nil
+	}
+	lines := strings.Split(text, "\n")
+	keep := make([]string, 0, len(lines))
+	for _, line := range lines {
+		v := strings.TrimSpace(line)
+		if !strings.HasPrefix(v, AnnotationPrefix) {
+			keep = append(keep, line)
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		v = strings.TrimPrefix(v, AnnotationPrefix)
+		key, value := v, ""
+		if i := strings.Index(v, "="); i >= 0 {
+			key, value = v[:i], v[i+1:]
+		}
+		annotations[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	rest = strings.Join(keep, "\n")
+	return
+}
@@ -20,7 +20,7 @@ type Package struct {
 // ParsePackage parse specified packages.
 func ParsePackage(patterns []string, tags ...string) (pkg *Package, err error) {
 	cfg := &packages.Config{
-		Mode:       packages.LoadSyntax,
+		Mode:       packages.LoadSyntax | packages.NeedDeps,
 		Tests:      false,
 		BuildFlags: []string{fmt.Sprintf("-tags=%s", strings.Join(tags, " "))},
 	}
@@ -41,7 +41,7 @@ func ParsePackage(patterns []string, tags ...string) (pkg *Package, err error) {
 // ParsePackage parse specified packages.
 func ParseMulPackage(patterns []string, tags ...string) (pkgs []*packages.Package, err error) {
 	cfg := &packages.Config{
-		Mode:       packages.LoadSyntax,
+		Mode:       packages.LoadSyntax | packages.NeedDeps,
 		Tests:      false,
 		BuildFlags: []string{fmt.Sprintf("-tags=%s", strings.Join(tags, " "))},
 	}
@@ -229,7 +229,7 @@ func (p *Package) Fset() *token.FileSet {
 // ParseGeneratePackage by go generate env
 func ParseGeneratePackage(tags ...string) (pkg *Package, err error) {
 	cfg := &packages.Config{
-		Mode:       packages.LoadSyntax,
+		Mode:       packages.LoadSyntax | packages.NeedDeps,
 		Tests:      false,
 		BuildFlags: []string{fmt.Sprintf("-tags=%s", strings.Join(tags, " "))},
 	}
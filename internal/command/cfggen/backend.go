@@ -0,0 +1,167 @@
+package cfggen
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+// backend config source which generated config read from
+type backend struct {
+	// template defines "imports" and "source" blocks
+	template string
+	// getters field type => get value expression.
+	// key based backends read by variable `key`, string based backends parse variable `s`.
+	getters map[string]getter
+	// text get string value expression, decode by encoding.TextUnmarshaler
+	text getter
+	// generic decode into variable `v` expression, return error
+	generic string
+}
+
+type getter struct {
+	// expr get value expression
+	expr string
+	// err expression return (value, error)
+	err bool
+}
+
+var backends = map[string]*backend{
+	"walle": {
+		template: walleTemplate,
+		getters: map[string]getter{
+			"uint":              {"cc.GetUint(key)", true},
+			"uint32":            {"cc.GetUint32(key)", true},
+			"uint8":             {"cc.GetUint32(key)", true},
+			"uint16":            {"cc.GetUint32(key)", true},
+			"uint64":            {"cc.GetUint64(key)", true},
+			"int":               {"cc.GetInt(key)", true},
+			"int32":             {"cc.GetInt32(key)", true},
+			"int8":              {"cc.GetInt32(key)", true},
+			"int16":             {"cc.GetInt32(key)", true},
+			"int64":             {"cc.GetInt64(key)", true},
+			"bool":              {"cc.GetBool(key)", true},
+			"float32":           {"cc.GetFloat64(key)", true},
+			"float64":           {"cc.GetFloat64(key)", true},
+			"string":            {"cc.GetString(key)", true},
+			"time.Duration":     {"cc.GetDuration(key)", true},
+			"[]int":             {"cc.GetIntSlice(key)", true},
+			"[]string":          {"cc.GetStringSlice(key)", true},
+			"map[string]string": {"cc.GetStringMapString(key)", true},
+		},
+		text:    getter{"cc.GetString(key)", true},
+		generic: "cc.GetObject(key, &v)",
+	},
+	"viper": {
+		template: viperTemplate,
+		getters:  viperGetters,
+		text:     getter{"vp.GetString(key)", false},
+		generic:  "vp.UnmarshalKey(key, &v)",
+	},
+	"file": {
+		template: viperTemplate + fileTemplate,
+		getters:  viperGetters,
+		text:     getter{"vp.GetString(key)", false},
+		generic:  "vp.UnmarshalKey(key, &v)",
+	},
+	"koanf": {
+		template: koanfTemplate,
+		getters: map[string]getter{
+			// koanf has no unsigned getter, Int64 overflow values larger than max int64
+			"uint":              {"strconv.ParseUint(k.String(key), 0, 0)", true},
+			"uint8":             {"strconv.ParseUint(k.String(key), 0, 8)", true},
+			"uint16":            {"strconv.ParseUint(k.String(key), 0, 16)", true},
+			"uint32":            {"strconv.ParseUint(k.String(key), 0, 32)", true},
+			"uint64":            {"strconv.ParseUint(k.String(key), 0, 64)", true},
+			"int":               {"k.Int(key)", false},
+			"int8":              {"k.Int64(key)", false},
+			"int16":             {"k.Int64(key)", false},
+			"int32":             {"k.Int64(key)", false},
+			"int64":             {"k.Int64(key)", false},
+			"bool":              {"k.Bool(key)", false},
+			"float32":           {"k.Float64(key)", false},
+			"float64":           {"k.Float64(key)", false},
+			"string":            {"k.String(key)", false},
+			"time.Duration":     {"k.Duration(key)", false},
+			"[]int":             {"k.Ints(key)", false},
+			"[]int64":           {"k.Int64s(key)", false},
+			"[]float64":         {"k.Float64s(key)", false},
+			"[]bool":            {"k.Bools(key)", false},
+			"[]string":          {"k.Strings(key)", false},
+			"map[string]string": {"k.StringMap(key)", false},
+		},
+		text:    getter{"k.String(key)", false},
+		generic: "k.Unmarshal(key, &v)",
+	},
+	"env": {
+		template: envTemplate,
+		getters:  stringGetters,
+		text:     getter{"s", false},
+		generic:  "json.Unmarshal([]byte(s), &v)",
+	},
+	"source": {
+		template: sourceTemplate,
+		getters:  stringGetters,
+		text:     getter{"s", false},
+		generic:  "json.Unmarshal([]byte(s), &v)",
+	},
+}
+
+// viperGetters viper getters, also used by file backend
+var viperGetters = map[string]getter{
+	"uint":              {"vp.GetUint(key)", false},
+	"uint8":             {"vp.GetUint32(key)", false},
+	"uint16":            {"vp.GetUint32(key)", false},
+	"uint32":            {"vp.GetUint32(key)", false},
+	"uint64":            {"vp.GetUint64(key)", false},
+	"int":               {"vp.GetInt(key)", false},
+	"int8":              {"vp.GetInt32(key)", false},
+	"int16":             {"vp.GetInt32(key)", false},
+	"int32":             {"vp.GetInt32(key)", false},
+	"int64":             {"vp.GetInt64(key)", false},
+	"bool":              {"vp.GetBool(key)", false},
+	"float32":           {"vp.GetFloat64(key)", false},
+	"float64":           {"vp.GetFloat64(key)", false},
+	"string":            {"vp.GetString(key)", false},
+	"time.Duration":     {"vp.GetDuration(key)", false},
+	"time.Time":         {"vp.GetTime(key)", false},
+	"[]int":             {"vp.GetIntSlice(key)", false},
+	"[]string":          {"vp.GetStringSlice(key)", false},
+	"map[string]string": {"vp.GetStringMapString(key)", false},
+}
+
+// stringGetters parse string value
+var stringGetters = map[string]getter{
+	"uint":          {"strconv.ParseUint(s, 0, 0)", true},
+	"uint8":         {"strconv.ParseUint(s, 0, 8)", true},
+	"uint16":        {"strconv.ParseUint(s, 0, 16)", true},
+	"uint32":        {"strconv.ParseUint(s, 0, 32)", true},
+	"uint64":        {"strconv.ParseUint(s, 0, 64)", true},
+	"int":           {"strconv.ParseInt(s, 0, 0)", true},
+	"int8":          {"strconv.ParseInt(s, 0, 8)", true},
+	"int16":         {"strconv.ParseInt(s, 0, 16)", true},
+	"int32":         {"strconv.ParseInt(s, 0, 32)", true},
+	"int64":         {"strconv.ParseInt(s, 0, 64)", true},
+	"bool":          {"strconv.ParseBool(s)", true},
+	"float32":       {"strconv.ParseFloat(s, 32)", true},
+	"float64":       {"strconv.ParseFloat(s, 64)", true},
+	"string":        {"s", false},
+	"time.Duration": {"time.ParseDuration(s)", true},
+	"[]string":      {"strings.Split(s, \",\")", false},
+	// element parsed by time.ParseDuration like time.Duration
+	"[]time.Duration": {"cfg.parseDurations(s)", true},
+}
+
+// getBackend get backend by name, exit if not support.
+func getBackend(name string) *backend {
+	b, ok := backends[name]
+	if !ok {
+		names := make([]string, 0, len(backends))
+		for k := range backends {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		log.Fatalf("backend %s not support, use one of %s", name, strings.Join(names, ","))
+	}
+	return b
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"log"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/aggronmagi/gogen/goparse"
@@ -19,9 +22,15 @@ var config = struct {
 	FuncWithOptionName bool
 	Output             string
 	Lowercase          bool
+	Backend            string
+	Atomic             bool
+	Schema             []string
+	Overlay            bool
+	ForTest            bool
 }{
 	AllExport: true,
 	Lowercase: true,
+	Backend:   "walle",
 }
 
 func FlagSet(set *pflag.FlagSet) {
@@ -47,10 +56,30 @@ func FlagSet(set *pflag.FlagSet) {
 	)
 	//
 	set.BoolVar(&config.Lowercase, "lower", config.Lowercase, "force lower case config name")
+	// 配置来源
+	set.StringVar(&config.Backend, "backend", config.Backend,
+		"config source backend, walle, viper, koanf, env, file(local file read by viper, watch by fsnotify) or source(generate <Name>Source interface)",
+	)
+	// 生成原子替换的Holder,刷新时构造新值再替换
+	set.BoolVar(&config.Atomic, "atomic", config.Atomic,
+		"generate <Name>Holder, refresh build new value and swap it in atomically",
+	)
+	// 生成环境变量和命令行参数覆盖层, 优先级 flag > env > 配置中心 > 默认值
+	set.BoolVar(&config.Overlay, "overlay", config.Overlay,
+		"generate overlay, value can be override by env and flag. flag > env > config source > default",
+	)
+	// 生成测试辅助函数和内存配置源, 输出到 <output>_fortest.go
+	set.BoolVar(&config.ForTest, "for-test", config.ForTest,
+		"generate With<Field>ForTest helpers and in-memory fake config source to <output>_fortest.go",
+	)
+	// 输出配置描述文件. json: JSON Schema, yaml,toml: 示例配置
+	set.StringSliceVar(&config.Schema, "schema", config.Schema,
+		"write config schema file, json(JSON Schema), yaml or toml(sample config with default value and document)",
+	)
 }
 
 // Version generate config command version
-var Version string = "0.0.1"
+var Version string = "0.0.12"
 
 func RunCommand(cmd *cobra.Command, args []string) {
 	// parse file from env, which was seted by go generate tool.
@@ -69,24 +98,82 @@ func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
 	node, cm, err := pkg.GetGenerateNode()
 	util.FatalIfErr(err, "find generate ast node failed")
 
-	// document and comment helper func
-	foreachComment := func(node ast.Node, fc func(g *ast.CommentGroup)) {
-		c, ok := cm[node]
-		if !ok {
-			return
-		}
-		for _, v := range c {
-			fc(v)
-		}
-		return
-	}
-
 	// Only receive func declare.
 	fdecl, ok := node.(*ast.FuncDecl)
 	if !ok {
 		util.Dump(node)
 		log.Fatal("find ast node is not func type")
 	}
+	optSt = parseDeclareFunc(pkg, collectDeclareFuncs(pkg), fdecl, cm, nil)
+	return
+}
+
+// declareFunc config declaration function
+type declareFunc struct {
+	decl *ast.FuncDecl
+	cm   ast.CommentMap
+}
+
+// collectDeclareFuncs collect config declaration functions in package.
+func collectDeclareFuncs(pkg *goparse.Package) (decls map[string]*declareFunc) {
+	decls = make(map[string]*declareFunc)
+	pkg.FuncDecl(func(decl *ast.FuncDecl, cm ast.CommentMap) bool {
+		if decl.Recv != nil || !strings.HasSuffix(decl.Name.Name, "DeclareWithDefault") {
+			return true
+		}
+		decls[decl.Name.Name] = &declareFunc{
+			decl: decl,
+			cm:   cm,
+		}
+		return true
+	})
+	return
+}
+
+// selfGenerate return cfggen go:generate directive of declaration function, empty if not has it's own directive.
+func selfGenerate(decl *ast.FuncDecl) string {
+	if decl.Doc == nil {
+		return ""
+	}
+	for _, c := range decl.Doc.List {
+		if strings.HasPrefix(c.Text, "//go:generate") && strings.Contains(c.Text, " cfggen") {
+			return c.Text
+		}
+	}
+	return ""
+}
+
+// directiveFlag value of flag in go:generate directive, bool flag without value is "true".
+// empty if flag not set.
+func directiveFlag(directive, name string) string {
+	args := strings.Fields(directive)
+	for k, arg := range args {
+		if v := strings.TrimPrefix(arg, "--"+name+"="); v != arg {
+			return v
+		}
+		if arg != "--"+name {
+			continue
+		}
+		if k+1 < len(args) && !strings.HasPrefix(args[k+1], "-") && name != "overlay" {
+			return args[k+1]
+		}
+		return "true"
+	}
+	return ""
+}
+
+// parseDeclareFunc parse config declaration function.
+// stack is declaration functions being parsed, used to check circular nested.
+func parseDeclareFunc(pkg *goparse.Package, decls map[string]*declareFunc, fdecl *ast.FuncDecl,
+	cm ast.CommentMap, stack []string) (optSt *optionStruct) {
+	for _, name := range stack {
+		if name == fdecl.Name.Name {
+			log.Fatal("circular nested config declaration: ",
+				strings.Join(append(stack, fdecl.Name.Name), " -> "))
+		}
+	}
+	stack = append(stack, fdecl.Name.Name)
+
 	// Only allow func has one statement
 	if len(fdecl.Body.List) != 1 {
 		log.Fatal("func not only have one stmt")
@@ -110,12 +197,33 @@ func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
 	optSt.FromFunc = fdecl.Name.Name
 	optSt.Name = optSt.FromFunc
 	// node document
-	foreachComment(node, func(g *ast.CommentGroup) {
-		if len(optSt.Document) > 0 {
-			optSt.Document += "\n"
+	if c, ok := cm[fdecl]; ok {
+		for _, g := range c {
+			if len(optSt.Document) > 0 {
+				optSt.Document += "\n"
+			}
+			optSt.Document += g.Text()
 		}
-		optSt.Document += g.Text()
-	})
+	}
+	parseFields(pkg, decls, cm, result, optSt, stack)
+	return
+}
+
+// parseFields parse map literal elements as config fields.
+func parseFields(pkg *goparse.Package, decls map[string]*declareFunc, cm ast.CommentMap,
+	result *ast.CompositeLit, optSt *optionStruct, stack []string) {
+	// document and comment helper func
+	foreachComment := func(node ast.Node, fc func(g *ast.CommentGroup)) {
+		c, ok := cm[node]
+		if !ok {
+			return
+		}
+		for _, v := range c {
+			fc(v)
+		}
+		return
+	}
+
 	// composite elements list
 	for k, elt := range result.Elts {
 		// element must be key/value pair literal.
@@ -152,6 +260,23 @@ func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
 		// maybe value comment
 		field.FieldType = FieldTypeVar
 
+		// nested config. declaration function or map literal
+		if name, ok := nestedDeclareFunc(kvexpr.Value); ok {
+			nested, ok := decls[name]
+			if !ok {
+				log.Fatal("nested config declaration ", name, " not found in package")
+			}
+			field.Nested = parseDeclareFunc(pkg, decls, nested.decl, nested.cm, stack)
+			field.Nested.Directive = selfGenerate(nested.decl)
+			field.Nested.External = field.Nested.Directive != ""
+			continue
+		}
+		if val, ok := kvexpr.Value.(*ast.CompositeLit); ok && isNestedMap(pkg, val) {
+			field.Nested = &optionStruct{}
+			parseFields(pkg, decls, cm, val, field.Nested, stack)
+			continue
+		}
+
 		switch val := kvexpr.Value.(type) {
 		case *ast.BasicLit:
 			// 基础类型常量
@@ -163,7 +288,7 @@ func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
 			case token.INT:
 				field.Type = "int"
 			case token.FLOAT:
-				field.Type = "float"
+				field.Type = "float64"
 			case token.CHAR:
 				field.Type = "byte"
 			case token.STRING:
@@ -225,8 +350,82 @@ func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
 		default:
 			util.Dump(kvexpr.Value)
 		}
+		field.typ = evalType(pkg, kvexpr.Value.Pos(), field.Type)
+		field.TextUnmarshaler = isTextUnmarshaler(field.typ)
+		field.Kind = underlyingKind(field.typ)
+		field.value = defaultValue(pkg, kvexpr.Value)
 	}
-	return
+}
+
+// evalType evaluate type expression typ in scope of pos, return nil if failed.
+func evalType(pkg *goparse.Package, pos token.Pos, typ string) types.Type {
+	if typ == "" {
+		return nil
+	}
+	tv, err := types.Eval(pkg.Fset(), pkg.Package().Types, pos, typ)
+	if err != nil || !tv.IsType() {
+		return nil
+	}
+	return tv.Type
+}
+
+// isTextUnmarshaler report whether pointer of typ implements encoding.TextUnmarshaler.
+func isTextUnmarshaler(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	switch typ.Underlying().(type) {
+	case *types.Pointer, *types.Interface:
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typ), true, nil, "UnmarshalText")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == 1 && sig.Results().Len() == 1 &&
+		sig.Params().At(0).Type().String() == "[]byte" &&
+		sig.Results().At(0).Type().String() == "error"
+}
+
+// underlyingKind kind of typ used by validation. string, number, length(slice or map) or empty.
+func underlyingKind(typ types.Type) string {
+	if typ == nil {
+		return ""
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		if t.Info()&types.IsString != 0 {
+			return "string"
+		}
+		if t.Info()&types.IsNumeric != 0 {
+			return "number"
+		}
+	case *types.Slice, *types.Map:
+		return "length"
+	}
+	return ""
+}
+
+// nestedDeclareFunc check value is call other config declaration function.
+// eg: "DB": DBConfigDeclareWithDefault(),
+func nestedDeclareFunc(expr ast.Expr) (name string, ok bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return "", false
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || !strings.HasSuffix(ident.Name, "DeclareWithDefault") {
+		return "", false
+	}
+	return ident.Name, true
+}
+
+// isNestedMap report whether value is nested config map literal. eg: map[string]interface{}{...}
+func isNestedMap(pkg *goparse.Package, val *ast.CompositeLit) bool {
+	typ := goparse.Format(pkg.Fset(), val.Type)
+	return typ == "map[string]interface{}" || typ == "map[string]any"
 }
 
 // convertCompositeLitBody composite lit body convert
@@ -262,21 +461,24 @@ func convertCompositeLitBody(pkg *goparse.Package, cm ast.CommentMap, val *ast.C
 				field.Comment = append(field.Comment, g.Text())
 			})
 		case *ast.KeyValueExpr:
-			blKey, okKey := elt.Key.(*ast.BasicLit)
-			blVal, okVal := elt.Value.(*ast.BasicLit)
-			if !okKey || !okVal {
-				log.Fatalf("[%d] type %s support basic types only", k, field.Type)
-			}
-			data = append(data, fmt.Sprintf("%s:%s", blKey.Value, blVal.Value))
+			data = append(data, fmt.Sprintf("%s:%s", goparse.Format(pkg.Fset(), elt.Key),
+				goparse.Format(pkg.Fset(), elt.Value)))
 			foreachComment(elt.Value, func(g *ast.CommentGroup) {
 				field.Comment = append(field.Comment, g.Text())
 			})
 		default:
-			err = fmt.Errorf("[%d] type %s not support. %T %#v", k, field.Type, elt, elt)
-			return
+			// eg: time.Second
+			data = append(data, goparse.Format(pkg.Fset(), elt))
+			foreachComment(elt, func(g *ast.CommentGroup) {
+				field.Comment = append(field.Comment, g.Text())
+			})
 		}
 	}
 	field.Body = "nil"
+	if !strings.HasPrefix(field.Type, "[]") && !strings.HasPrefix(field.Type, "map[") {
+		// struct value. eg: time.Time{}
+		field.Body = field.Type + "{}"
+	}
 	if len(data) > 0 {
 		field.Body = fmt.Sprintf("%s{%s}",
 			field.Type, strings.Join(data, ","))
@@ -298,13 +500,44 @@ type optionField struct {
 	Name      string
 	Type      string
 	Body      string
-	GetMethod string
+	// Getter get value expression of backend
+	Getter string
+	// GetterErr getter return (value, error)
+	GetterErr bool
+	// Nested nested config
+	Nested *optionStruct
+	// Decode decode mode. getter: backend getter, text: encoding.TextUnmarshaler,
+	// generic: backend generic decode. set by `gogen:decode=<mode>`
+	Decode string
+	// TextUnmarshaler field type implements encoding.TextUnmarshaler
+	TextUnmarshaler bool
+	// Kind underlying kind of field type, string, number, length(slice or map) or empty.
+	Kind string
+	// RangeMin RangeMax value range, length range of string, slice and map. set by `gogen:range=<min>,<max>`
+	RangeMin string
+	RangeMax string
+	// Regexp string value pattern, set by `gogen:regex=<pattern>`
+	Regexp string
+	// OneOf allowed values, set by `gogen:oneof=a,b,c`
+	OneOf []string
+	// Required value must not be zero, set by `gogen:required`
+	Required bool
+	// Secret value is masked in String and LogValue, not set to config source as default.
+	// string value is resolved by secret resolver. set by `gogen:secret`
+	Secret bool
+	// Overlay decode string value of env and flag
+	Overlay *optionField
+	// typ evaluated field type, nil if failed
+	typ types.Type
+	// value evaluated default value used by schema, nil if not constant
+	value interface{}
 
 	Export bool
 }
 
 func (field *optionField) fix() {
 	field.Name = strings.Trim(field.Name, "\"")
+	field.parseAnnotations()
 	field.Export = true
 	if !token.IsExported(field.Name) {
 		field.Export = false
@@ -323,6 +556,97 @@ func (field *optionField) fix() {
 	}
 }
 
+// parseAnnotations parse gogen annotations from document and comment
+func (field *optionField) parseAnnotations() {
+	annotations := make(map[string]string)
+	merge := func(text string) string {
+		rest, list := goparse.ParseAnnotations(text)
+		for k, v := range list {
+			annotations[k] = v
+		}
+		return rest
+	}
+	field.Document = merge(field.Document)
+	for k, v := range field.Comment {
+		field.Comment[k] = merge(v)
+	}
+	for k, v := range annotations {
+		switch k {
+		case "decode":
+			switch v {
+			case "getter", "text", "generic":
+				field.Decode = v
+			default:
+				log.Fatalf("field %s decode mode %s not support, use getter, text or generic", field.Name, v)
+			}
+		case "range":
+			list := strings.Split(v, ",")
+			if len(list) != 2 || (strings.TrimSpace(list[0]) == "" && strings.TrimSpace(list[1]) == "") {
+				log.Fatalf("field %s range %s invalid, use gogen:range=<min>,<max>", field.Name, v)
+			}
+			field.RangeMin = strings.TrimSpace(list[0])
+			field.RangeMax = strings.TrimSpace(list[1])
+		case "regex":
+			if _, err := regexp.Compile(v); err != nil {
+				log.Fatalf("field %s regex %s invalid: %v", field.Name, v, err)
+			}
+			field.Regexp = v
+		case "oneof":
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					field.OneOf = append(field.OneOf, item)
+				}
+			}
+			if len(field.OneOf) == 0 {
+				log.Fatalf("field %s oneof is empty", field.Name)
+			}
+		case "required":
+			field.Required = true
+		case "secret":
+			field.Secret = true
+		default:
+			log.Printf("field %s annotation %s not support,ignore\n", field.Name, k)
+		}
+	}
+}
+
+// hasValidation field has validation annotations
+func (field *optionField) hasValidation() bool {
+	return field.RangeMin != "" || field.RangeMax != "" || field.Regexp != "" || len(field.OneOf) > 0 || field.Required
+}
+
+// fixValidation check validation annotations match field type.
+func (field *optionField) fixValidation(st *optionStruct) {
+	if field.Secret && field.Nested != nil {
+		log.Fatalf("struct %s field %s is nested config, not support secret", st.Name, field.Name)
+	}
+	if !field.hasValidation() {
+		return
+	}
+	if field.Nested != nil {
+		log.Fatalf("struct %s field %s is nested config, not support validation annotations", st.Name, field.Name)
+	}
+	if (field.RangeMin != "" || field.RangeMax != "") && field.Kind == "" {
+		log.Fatalf("struct %s field %s type %s not support range, need number, string, slice or map", st.Name, field.Name, field.Type)
+	}
+	if field.Regexp != "" && field.Kind != "string" {
+		log.Fatalf("struct %s field %s type %s not support regex, need string", st.Name, field.Name, field.Type)
+	}
+	if len(field.OneOf) > 0 {
+		switch field.Kind {
+		case "string":
+			for k, v := range field.OneOf {
+				if !strings.HasPrefix(v, "\"") && !strings.HasPrefix(v, "`") {
+					field.OneOf[k] = strconv.Quote(v)
+				}
+			}
+		case "number":
+		default:
+			log.Fatalf("struct %s field %s type %s not support oneof, need number or string", st.Name, field.Name, field.Type)
+		}
+	}
+}
+
 func (field *optionField) GenFuncName(st *optionStruct) string {
 	suffix := strings.Title(field.Name)
 	if config.FuncWithOptionName {
@@ -361,12 +685,115 @@ type optionStruct struct {
 	FromFunc   string
 	OptionName string
 	Fields     []*optionField
+	// Child config nested in other config
+	Child bool
+	// External nested config generated by it's own go:generate directive
+	External bool
+	// Directive go:generate directive of external nested config
+	Directive string
+	// Atomic generate holder of top level config
+	Atomic bool
+	// Overlay override value by env and flag
+	Overlay bool
+	// Secret config or nested configs has secret fields
+	Secret bool
+	// ForTest generate test helpers
+	ForTest bool
 }
 
 func (opt *optionStruct) fixStruct() {
+	opt.Atomic = config.Atomic
+	opt.Overlay = config.Overlay
+	opt.ForTest = config.ForTest
+	opt.fixName(config.OptionsName)
+	opt.fixFields()
+	opt.fixSecret()
+}
+
+// ParseDurations fields of this level parse duration list from string.
+func (opt *optionStruct) ParseDurations() bool {
+	for _, f := range opt.Fields {
+		if strings.HasPrefix(f.Getter, "cfg.parseDurations(") ||
+			(f.Overlay != nil && strings.HasPrefix(f.Overlay.Getter, "cfg.parseDurations(")) {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretFields secret fields of this level
+func (opt *optionStruct) SecretFields() (list []*optionField) {
+	for _, f := range opt.Fields {
+		if f.Secret {
+			list = append(list, f)
+		}
+	}
+	return
+}
+
+// leafField not nested field of config or nested configs, holder register change funcs by it.
+type leafField struct {
+	// Method method name suffix, eg: DBPort
+	Method string
+	// Path field path from top level config, eg: DB.Port
+	Path string
+	// Key key relative to prefix of top level config, eg: db.port
+	Key  string
+	Type string
+}
+
+// LeafFields not nested fields of config and nested configs
+func (opt *optionStruct) LeafFields() (list []leafField) {
+	var walk func(st *optionStruct, method, path, key string)
+	walk = func(st *optionStruct, method, path, key string) {
+		for _, f := range st.Fields {
+			if f.Nested != nil {
+				walk(f.Nested, method+CamelCase(f.Name), path+f.Name+".", key+keyName(f.Name)+".")
+				continue
+			}
+			list = append(list, leafField{
+				Method: method + CamelCase(f.Name),
+				Path:   path + f.Name,
+				Key:    key + keyName(f.Name),
+				Type:   f.Type,
+			})
+		}
+	}
+	walk(opt, "", "", "")
+	return
+}
+
+// fixSecret mark config and nested configs if any of them has secret fields.
+func (opt *optionStruct) fixSecret() {
+	var has func(st *optionStruct) bool
+	has = func(st *optionStruct) bool {
+		for _, f := range st.Fields {
+			if f.Secret || (f.Nested != nil && has(f.Nested)) {
+				return true
+			}
+		}
+		return false
+	}
+	if !has(opt) {
+		return
+	}
+	var mark func(st *optionStruct)
+	mark = func(st *optionStruct) {
+		st.Secret = true
+		for _, f := range st.Fields {
+			if f.Nested != nil {
+				mark(f.Nested)
+			}
+		}
+	}
+	mark(opt)
+}
+
+// fixName fix config type name, use declaration function name if name is empty.
+func (opt *optionStruct) fixName(name string) {
 	// Option Name fix
-	if config.OptionsName != "" {
-		opt.Name = strings.Title(config.OptionsName)
+	if name != "" {
+		opt.Name = strings.Title(name)
 		if strings.HasSuffix(opt.Name, "Config") {
 			opt.OptionName = opt.Name
 			//opt.Name += "s"
@@ -390,8 +817,12 @@ func (opt *optionStruct) fixStruct() {
 			// opt.Name += "Options"
 		}
 	}
+}
+
+func (opt *optionStruct) fixFields() {
 	for _, f := range opt.Fields {
 		f.fix()
+		f.fixValidation(opt)
 	}
 	// all export config
 	if config.AllExport {
@@ -399,55 +830,148 @@ func (opt *optionStruct) fixStruct() {
 			f.Name = strings.Title(f.Name)
 		}
 	}
+	// nested config, read from key "<prefix>.<name>"
+	for _, f := range opt.Fields {
+		if f.Nested == nil {
+			continue
+		}
+		f.Nested.Child = true
+		f.Nested.Overlay = opt.Overlay
+		if f.Nested.External {
+			f.Nested.checkExternal(opt)
+		}
+		f.Nested.ForTest = opt.ForTest
+		if f.Nested.FromFunc == "" {
+			// map literal
+			f.Nested.Name = opt.Name + strings.Title(f.Name)
+			f.Nested.OptionName = f.Nested.Name
+		} else {
+			f.Nested.fixName("")
+		}
+		f.Nested.fixFields()
+		f.Type = "*" + f.Nested.Name
+		f.Body = fmt.Sprintf("NewDefault%s(prefix + %q)", f.Nested.Name, "."+keyName(f.Name))
+	}
 	//
-	opt.fixFieldsGetMethod()
+	opt.fixFieldsGetMethod(getBackend(config.Backend))
 }
 
-func (opt *optionStruct) fixFieldsGetMethod() {
+// checkExternal check external nested config generated with options parent required.
+// generated code of parent call methods of nested config which generated by these options.
+func (opt *optionStruct) checkExternal(parent *optionStruct) {
+	// backends read by same source type are compatible
+	same := map[string]string{"file": "viper", "env": "source"}
+	kind := func(name string) string {
+		if v, ok := same[name]; ok {
+			return v
+		}
+		return name
+	}
+	backend := directiveFlag(opt.Directive, "backend")
+	if backend == "" {
+		backend = "walle"
+	}
+	if kind(backend) != kind(config.Backend) {
+		log.Fatalf("%s nested config %s generated with backend %s, must use the same backend %s. directive: %s",
+			parent.FromFunc, opt.FromFunc, backend, config.Backend, opt.Directive)
+	}
+	if parent.Overlay && directiveFlag(opt.Directive, "overlay") != "true" {
+		log.Fatalf("%s generated with --overlay, nested config %s must be generated with --overlay too. directive: %s",
+			parent.FromFunc, opt.FromFunc, opt.Directive)
+	}
+}
+
+// generateList config and nested configs need generate
+func (opt *optionStruct) generateList() (list []*optionStruct) {
+	exists := make(map[string]bool)
+	var walk func(st *optionStruct)
+	walk = func(st *optionStruct) {
+		if st.External || exists[st.Name] {
+			return
+		}
+		exists[st.Name] = true
+		list = append(list, st)
+		for _, f := range st.Fields {
+			if f.Nested != nil {
+				walk(f.Nested)
+			}
+		}
+	}
+	walk(opt)
+	return
+}
+
+// fixFieldsGetMethod select decode mode of fields. use backend getter of type,
+// otherwise decode by encoding.TextUnmarshaler or backend generic decode.
+func (opt *optionStruct) fixFieldsGetMethod(b *backend) {
 	fields := opt.Fields
 	newFields := make([]*optionField, 0, len(fields))
 	for _, f := range fields {
-		switch f.Type {
-		case "uint":
-			f.GetMethod = "GetUint"
-		case "uint32":
-			f.GetMethod = "GetUint32"
-		case "uint8", "uint16":
-			f.GetMethod = "GetUint32"
-		case "uint64":
-			f.GetMethod = "GetUint64"
-		case "int":
-			f.GetMethod = "GetInt"
-		case "int32":
-			f.GetMethod = "GetInt32"
-		case "int8", "int16":
-			f.GetMethod = "GetInt32"
-		case "int64":
-			f.GetMethod = "GetInt64"
-		case "bool":
-			f.GetMethod = "GetBool"
-		case "float32", "floa64":
-			f.GetMethod = "GetFloat64"
-		case "string":
-			f.GetMethod = "GetString"
-		case "time.Duration":
-			f.GetMethod = "GetDuration"
-		case "[]int":
-			f.GetMethod = "GetIntSlice"
-		case "[]string":
-			f.GetMethod = "GetStringSlice"
-		case "map[string]string":
-			f.GetMethod = "GetStringMapString"
-		}
-		if f.GetMethod == "" {
-			log.Printf("struct %s field:%#v type not support,ignore\n", opt.Name, f)
+		if f.Nested != nil {
+			newFields = append(newFields, f)
 			continue
 		}
+		if f.Type == "" {
+			log.Printf("struct %s field:%#v type unknown,ignore\n", opt.Name, f)
+			continue
+		}
+		f.fixDecode(opt, b, config.Backend)
+		if opt.Overlay {
+			// overlay parse string value of env and flag
+			overlay := *f
+			overlay.Decode = ""
+			overlay.fixDecode(opt, backends["env"], "env")
+			f.Overlay = &overlay
+		}
 		newFields = append(newFields, f)
 	}
 	opt.Fields = newFields
 }
 
+// fixDecode select decode mode and getter of backend
+func (f *optionField) fixDecode(opt *optionStruct, b *backend, name string) {
+	g, ok := b.getters[f.Type]
+	switch f.Decode {
+	case "":
+		f.Decode = "generic"
+		if ok {
+			f.Decode = "getter"
+		} else if f.TextUnmarshaler {
+			f.Decode = "text"
+		}
+	case "getter":
+		if !ok {
+			log.Fatalf("struct %s field %s type %s not has getter of backend %s", opt.Name, f.Name, f.Type, name)
+		}
+	case "text":
+		if !f.TextUnmarshaler {
+			log.Fatalf("struct %s field %s type %s not implements encoding.TextUnmarshaler", opt.Name, f.Name, f.Type)
+		}
+	}
+	switch f.Decode {
+	case "getter":
+		f.Getter = g.expr
+		f.GetterErr = g.err
+	case "text":
+		f.Getter = b.text.expr
+		f.GetterErr = b.text.err
+	case "generic":
+		f.Getter = b.generic
+		f.GetterErr = true
+	}
+}
+
+// Doc document of config, use first comment if document is empty.
+func (opt *optionStruct) Doc() string {
+	if len(opt.Document) > 0 {
+		return strings.TrimSpace(opt.Document)
+	}
+	if len(opt.Comment) > 0 {
+		return strings.TrimSpace(opt.Comment[0])
+	}
+	return ""
+}
+
 func (opt *optionField) Doc() string {
 	if len(opt.Document) > 0 {
 		return strings.TrimSpace(opt.Document)
@@ -0,0 +1,53 @@
+package cfggen
+
+import (
+	"testing"
+
+	"github.com/aggronmagi/gogen/internal/gentest"
+	"github.com/spf13/cobra"
+)
+
+var defaultConfig = config
+
+// modules used by generated code
+const (
+	modViper  = "github.com/spf13/viper v1.21.0"
+	modKoanf  = "github.com/knadh/koanf/v2 v2.3.7"
+	modNotify = "github.com/fsnotify/fsnotify v1.10.1"
+	modPflag  = "github.com/spf13/pflag v1.0.10"
+	// walle is replaced by stub module testdata/wallestub
+	modWalle = "github.com/walleframe/walle"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := []gentest.Case{
+		{Dir: "walle", Replace: map[string]string{modWalle: "wallestub"}},
+		{Dir: "viper", Requires: []string{modViper}},
+		{Dir: "koanf", Requires: []string{modKoanf}},
+		{Dir: "source"},
+		{Dir: "nested", Requires: []string{modViper}},
+		{Dir: "env"},
+		{Dir: "atomic", Requires: []string{modViper}},
+		{Dir: "validate"},
+		{Dir: "notify"},
+		{Dir: "schema"},
+		{Dir: "overlay", Requires: []string{modViper, modPflag}},
+		{Dir: "secret", Requires: []string{modViper}},
+		{Dir: "file", Requires: []string{modViper, modNotify}},
+		{Dir: "fortest"},
+		{Dir: "wallefortest", Requires: []string{modViper}, Replace: map[string]string{modWalle: "wallestub"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Dir, func(t *testing.T) {
+			c.Run(t, func(args []string) {
+				config = defaultConfig
+				cmd := &cobra.Command{Use: "cfggen"}
+				FlagSet(cmd.Flags())
+				if err := cmd.ParseFlags(args); err != nil {
+					t.Fatal(err)
+				}
+				RunCommand(cmd, cmd.Flags().Args())
+			})
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cfggen
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -43,12 +44,9 @@ func init() {
 		return in
 	}
 
-	UseFuncMap["ToLower"] = func(in string) string {
-		if config.Lowercase {
-			return strings.ToLower(in)
-		}
-		return in
-	}
+	UseFuncMap["ToLower"] = keyName
+	UseFuncMap["Quote"] = strconv.Quote
+	UseFuncMap["Join"] = strings.Join
 	//UseFuncMap["RegName"] = GetRegName
 	UseFuncMap["Tag"] = func(f string, v ...string) string {
 		for k := range v {
@@ -58,6 +56,14 @@ func init() {
 	}
 }
 
+// keyName config key of field name
+func keyName(in string) string {
+	if config.Lowercase {
+		return strings.ToLower(in)
+	}
+	return in
+}
+
 func CamelCase(s string) string {
 	if s == "" {
 		return ""
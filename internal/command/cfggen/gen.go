@@ -22,18 +22,25 @@ func generate(pkg *goparse.Package, st *optionStruct) {
 		PackageName string
 		*optionStruct
 		Imports map[string]*packages.Package
+		// Structs config and nested configs
+		Structs []*optionStruct
 	}{
 		Commands:     strings.Join(os.Args[1:], " "),
 		Version:      Version,
 		PackageName:  pkg.Package().Name,
 		optionStruct: st,
 		Imports:      pkg.Package().Imports,
+		Structs:      st.generateList(),
 	}
 
 	tmpl, err := template.New("cfggen").Funcs(UseFuncMap).Parse(configTemplate)
 	if err != nil {
 		log.Fatal(err)
 	}
+	_, err = tmpl.Parse(getBackend(config.Backend).template)
+	if err != nil {
+		log.Fatal(err)
+	}
 	var buf = bytes.NewBuffer(nil)
 	err = tmpl.Execute(buf, value)
 	if err != nil {
@@ -52,4 +59,22 @@ func generate(pkg *goparse.Package, st *optionStruct) {
 	err = ioutil.WriteFile(file, fd, 0644)
 	log.Println("==>", config.Output)
 	util.FatalIfErr(err, "format and write result")
+
+	// test helpers import testing, written to separate file, can be used by tests of other packages
+	if st.ForTest {
+		buf.Reset()
+		err = tmpl.ExecuteTemplate(buf, "test-file", value)
+		if err != nil {
+			log.Fatal("exec test template failed.", err)
+		}
+		fd, err = gen.OptionGoimportsFormtat(buf.Bytes())
+		if err != nil {
+			log.Println(buf.String())
+			log.Fatal("format go code failed", err)
+		}
+		err = ioutil.WriteFile(strings.TrimSuffix(file, ".go")+"_fortest.go", fd, 0644)
+		util.FatalIfErr(err, "write test helpers")
+	}
+
+	generateSchema(st, strings.TrimSuffix(file, ".go"))
 }
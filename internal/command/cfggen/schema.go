@@ -0,0 +1,384 @@
+package cfggen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aggronmagi/gogen/goparse"
+	"github.com/aggronmagi/gogen/internal/util"
+)
+
+// schemaFormats output format => file suffix
+var schemaFormats = map[string]string{
+	"json": ".schema.json",
+	"yaml": ".sample.yaml",
+	"toml": ".sample.toml",
+}
+
+// generateSchema write JSON Schema and sample config of config struct.
+// file name is base name of generated go file with format suffix.
+func generateSchema(st *optionStruct, base string) {
+	for _, format := range config.Schema {
+		suffix, ok := schemaFormats[format]
+		if !ok {
+			log.Fatalf("schema format %s not support, use json, yaml or toml", format)
+		}
+		var data []byte
+		switch format {
+		case "json":
+			data = st.jsonSchema()
+		case "yaml":
+			data = st.sampleYAML()
+		case "toml":
+			data = st.sampleTOML()
+		}
+		file := base + suffix
+		err := ioutil.WriteFile(file, data, 0644)
+		log.Println("==>", file)
+		util.FatalIfErr(err, "write schema")
+	}
+}
+
+// defaultValue evaluate default value expression. constant, slice and map literal of constants are supported,
+// time.Duration is formatted as string. return nil if can not evaluate.
+func defaultValue(pkg *goparse.Package, expr ast.Expr) interface{} {
+	info := pkg.Package().TypesInfo
+	tv, ok := info.Types[expr]
+	if !ok {
+		return nil
+	}
+	if tv.Value != nil {
+		v := constantValue(tv.Value)
+		if n, ok := v.(int64); ok && tv.Type.String() == "time.Duration" {
+			return time.Duration(n).String()
+		}
+		return v
+	}
+	switch val := expr.(type) {
+	case *ast.ParenExpr:
+		return defaultValue(pkg, val.X)
+	case *ast.CompositeLit:
+		switch tv.Type.Underlying().(type) {
+		case *types.Slice, *types.Array:
+			list := make([]interface{}, 0, len(val.Elts))
+			for _, elt := range val.Elts {
+				if _, ok := elt.(*ast.KeyValueExpr); ok {
+					return nil
+				}
+				v := defaultValue(pkg, elt)
+				if v == nil {
+					return nil
+				}
+				list = append(list, v)
+			}
+			return list
+		case *types.Map:
+			m := make(map[string]interface{}, len(val.Elts))
+			for _, elt := range val.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					return nil
+				}
+				k, v := defaultValue(pkg, kv.Key), defaultValue(pkg, kv.Value)
+				if k == nil || v == nil {
+					return nil
+				}
+				m[fmt.Sprint(k)] = v
+			}
+			return m
+		}
+	}
+	return nil
+}
+
+// constantValue convert constant to go value
+func constantValue(v constant.Value) interface{} {
+	switch v.Kind() {
+	case constant.Bool:
+		return constant.BoolVal(v)
+	case constant.String:
+		return constant.StringVal(v)
+	case constant.Int:
+		if n, ok := constant.Int64Val(v); ok {
+			return n
+		}
+		if n, ok := constant.Uint64Val(v); ok {
+			return n
+		}
+	case constant.Float:
+		n, _ := constant.Float64Val(v)
+		return n
+	}
+	return nil
+}
+
+// jsonSchema JSON Schema of config
+func (opt *optionStruct) jsonSchema() []byte {
+	schema := opt.objectSchema()
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = opt.Name
+	data, err := json.MarshalIndent(schema, "", "  ")
+	util.FatalIfErr(err, "marshal json schema")
+	return append(data, '\n')
+}
+
+// objectSchema schema of config object, properties named by config key.
+func (opt *optionStruct) objectSchema() map[string]interface{} {
+	properties := make(map[string]interface{}, len(opt.Fields))
+	var required []string
+	for _, f := range opt.Fields {
+		key := keyName(f.Name)
+		properties[key] = f.schema()
+		if f.Required {
+			required = append(required, key)
+		}
+	}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if doc := opt.Doc(); doc != "" {
+		schema["description"] = doc
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// schema schema of field, include default value, document and validation annotations.
+func (field *optionField) schema() map[string]interface{} {
+	if field.Nested != nil {
+		schema := field.Nested.objectSchema()
+		if doc := field.Doc(); doc != "" {
+			schema["description"] = doc
+		}
+		return schema
+	}
+	// config value is text of go type, default value and validation of go value not match.
+	text := field.Decode == "text" && field.Kind != "string" && field.Type != "time.Time"
+	schema := typeSchema(field.typ)
+	if text {
+		schema = map[string]interface{}{"type": "string"}
+	}
+	if doc := field.Doc(); doc != "" {
+		schema["description"] = doc
+	}
+	if text {
+		return schema
+	}
+	if v := field.sampleValue(); v != nil {
+		schema["default"] = v
+	}
+	if field.Secret {
+		schema["writeOnly"] = true
+	}
+	// validation annotations
+	if field.RangeMin != "" || field.RangeMax != "" {
+		names := [2]string{"minimum", "maximum"}
+		switch {
+		case field.Kind == "string":
+			names = [2]string{"minLength", "maxLength"}
+		case schema["type"] == "array":
+			names = [2]string{"minItems", "maxItems"}
+		case schema["type"] == "object":
+			names = [2]string{"minProperties", "maxProperties"}
+		}
+		for k, v := range []string{field.RangeMin, field.RangeMax} {
+			// range of go expression is not supported. eg: time.Second
+			if n, err := strconv.ParseFloat(v, 64); err == nil {
+				schema[names[k]] = n
+			}
+		}
+	}
+	if field.Regexp != "" {
+		schema["pattern"] = field.Regexp
+	}
+	if len(field.OneOf) > 0 {
+		enum := make([]interface{}, 0, len(field.OneOf))
+		for _, v := range field.OneOf {
+			if s, err := strconv.Unquote(v); err == nil {
+				enum = append(enum, s)
+			} else if n, err := strconv.ParseFloat(v, 64); err == nil {
+				enum = append(enum, n)
+			}
+		}
+		schema["enum"] = enum
+	}
+	return schema
+}
+
+// sampleValue default value write to schema and sample. text decoded field need string value,
+// default value of secret field is not written.
+func (field *optionField) sampleValue() interface{} {
+	if field.Secret {
+		return nil
+	}
+	if _, ok := field.value.(string); !ok && field.Decode == "text" {
+		return nil
+	}
+	return field.value
+}
+
+// typeSchema schema type of go type
+func typeSchema(typ types.Type) map[string]interface{} {
+	schema := make(map[string]interface{})
+	if typ == nil {
+		return schema
+	}
+	switch typ.String() {
+	case "time.Duration":
+		schema["type"] = "string"
+		schema["format"] = "duration"
+		return schema
+	case "time.Time":
+		schema["type"] = "string"
+		schema["format"] = "date-time"
+		return schema
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsBoolean != 0:
+			schema["type"] = "boolean"
+		case t.Info()&types.IsInteger != 0:
+			schema["type"] = "integer"
+		case t.Info()&types.IsFloat != 0:
+			schema["type"] = "number"
+		case t.Info()&types.IsString != 0:
+			schema["type"] = "string"
+		}
+	case *types.Slice:
+		schema["type"] = "array"
+		schema["items"] = typeSchema(t.Elem())
+	case *types.Array:
+		schema["type"] = "array"
+		schema["items"] = typeSchema(t.Elem())
+	case *types.Map:
+		schema["type"] = "object"
+		schema["additionalProperties"] = typeSchema(t.Elem())
+	case *types.Struct:
+		schema["type"] = "object"
+	}
+	return schema
+}
+
+// sampleYAML commented sample yaml config with default value.
+func (opt *optionStruct) sampleYAML() []byte {
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "# Code generated by \"gogen cfggen\"; DO NOT EDIT.\n")
+	writeComment(buf, "", opt.Doc())
+	opt.writeYAML(buf, "")
+	return buf.Bytes()
+}
+
+func (opt *optionStruct) writeYAML(buf *bytes.Buffer, indent string) {
+	for _, f := range opt.Fields {
+		writeComment(buf, indent, f.Doc())
+		key := keyName(f.Name)
+		switch {
+		case f.Nested != nil:
+			fmt.Fprintf(buf, "%s%s:\n", indent, key)
+			f.Nested.writeYAML(buf, indent+"  ")
+		case f.Secret:
+			fmt.Fprintf(buf, "%s# %s:\n", indent, key)
+		case f.sampleValue() != nil:
+			data, err := json.Marshal(f.sampleValue())
+			util.FatalIfErr(err, "marshal default value")
+			fmt.Fprintf(buf, "%s%s: %s\n", indent, key, data)
+		default:
+			// default value can not evaluate, write go expression as comment
+			fmt.Fprintf(buf, "%s# %s: %s\n", indent, key, f.Body)
+		}
+	}
+}
+
+// sampleTOML commented sample toml config with default value.
+func (opt *optionStruct) sampleTOML() []byte {
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "# Code generated by \"gogen cfggen\"; DO NOT EDIT.\n")
+	writeComment(buf, "", opt.Doc())
+	opt.writeTOML(buf, nil)
+	return buf.Bytes()
+}
+
+func (opt *optionStruct) writeTOML(buf *bytes.Buffer, path []string) {
+	// values of table must write before sub tables
+	for _, f := range opt.Fields {
+		if f.Nested != nil {
+			continue
+		}
+		writeComment(buf, "", f.Doc())
+		key := keyName(f.Name)
+		if f.Secret {
+			fmt.Fprintf(buf, "# %s =\n", key)
+		} else if v := f.sampleValue(); v != nil {
+			fmt.Fprintf(buf, "%s = %s\n", key, tomlValue(v))
+		} else {
+			fmt.Fprintf(buf, "# %s = %s\n", key, f.Body)
+		}
+	}
+	for _, f := range opt.Fields {
+		if f.Nested == nil {
+			continue
+		}
+		table := append(append([]string{}, path...), keyName(f.Name))
+		buf.WriteString("\n")
+		writeComment(buf, "", f.Doc())
+		fmt.Fprintf(buf, "[%s]\n", strings.Join(table, "."))
+		f.Nested.writeTOML(buf, table)
+	}
+}
+
+// tomlValue format value as toml
+func tomlValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		data, _ := json.Marshal(v)
+		return string(data)
+	case float64:
+		s := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			list = append(list, tomlValue(item))
+		}
+		return "[" + strings.Join(list, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		list := make([]string, 0, len(v))
+		for _, k := range keys {
+			list = append(list, tomlValue(k)+" = "+tomlValue(v[k]))
+		}
+		return "{ " + strings.Join(list, ", ") + " }"
+	}
+	return fmt.Sprint(v)
+}
+
+// writeComment write document as comment lines
+func writeComment(buf *bytes.Buffer, indent, doc string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		fmt.Fprintf(buf, "%s# %s\n", indent, strings.TrimSpace(line))
+	}
+}
@@ -0,0 +1,14 @@
+package atomic
+
+import "time"
+
+//go:generate gogen cfggen --backend viper --atomic
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Addr":    ":8080",
+		"Timeout": time.Duration(time.Second),
+		"DB": map[string]interface{}{
+			"Host": "127.0.0.1",
+		},
+	}
+}
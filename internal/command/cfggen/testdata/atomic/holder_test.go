@@ -0,0 +1,42 @@
+package atomic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestHolderNotify(t *testing.T) {
+	vp := viper.New()
+	h, err := NewServerConfigHolder("server", vp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hosts []string
+	notified := 0
+	h.OnDBHostChange(func(old, new string) { hosts = append(hosts, old+"->"+new) })
+	h.AddNotifyFunc(func(old, new *ServerConfig) { notified++ })
+	snapshot := h.Load()
+
+	vp.Set("server.db.host", "10.0.0.1")
+	if err := h.RefreshValue(vp); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hosts, []string{"127.0.0.1->10.0.0.1"}) || notified != 1 {
+		t.Fatal(hosts, notified)
+	}
+	// published snapshot keep unchanged
+	if snapshot.DB.Host != "127.0.0.1" || h.Load().DB.Host != "10.0.0.1" {
+		t.Fatal(snapshot.DB.Host, h.Load().DB.Host)
+	}
+
+	// not changed, not notified, refreshed copy still swapped in
+	current := h.Load()
+	if err := h.RefreshValue(vp); err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 || notified != 1 || h.Load() == current {
+		t.Fatal(hosts, notified)
+	}
+}
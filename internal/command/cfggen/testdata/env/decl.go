@@ -0,0 +1,24 @@
+package env
+
+import (
+	"net"
+	"time"
+)
+
+//go:generate gogen cfggen --backend env
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Addr":      ":8080",
+		"Level":     uint8(1),
+		"Ratio":     float32(0.5),
+		"Debug":     false,
+		"Timeout":   time.Duration(time.Second),
+		"Hosts":     []string{"a", "b"},
+		"Intervals": []time.Duration{time.Second},
+		"Start":     time.Time{},      // text
+		"IP":        net.IP(nil),      // text
+		"Ports":     []int64{80, 443}, // generic
+		// gogen:decode=generic
+		"Names": []string{"x"},
+	}
+}
@@ -0,0 +1,14 @@
+package file
+
+import "time"
+
+//go:generate gogen cfggen --backend file
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		// listen address
+		"Addr":    ":8080",
+		"Timeout": time.Duration(time.Second), // request timeout
+		"Hosts":   []string{"a", "b"},
+		"Weights": map[string]int{"a": 1},
+	}
+}
@@ -0,0 +1,9 @@
+package fortest
+
+//go:generate gogen cfggen --backend source --atomic --for-test
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Addr": ":8080",
+		"Port": 8080,
+	}
+}
@@ -0,0 +1,22 @@
+package fortest
+
+import "testing"
+
+func TestForTest(t *testing.T) {
+	h, err := NewServerConfigHolder("server", ServerConfigMapSource{"server.port": "9090"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports := 0
+	h.OnPortChange(func(old, new int) { ports++ })
+	t.Run("update", func(t *testing.T) {
+		h.UpdateForTest(t, func(cfg *ServerConfig) { cfg.Port = 7070 })
+		if h.Load().Port != 7070 || ports != 1 {
+			t.Fatal(h.Load().Port, ports)
+		}
+	})
+	// restored by cleanup
+	if h.Load().Port != 9090 || ports != 2 {
+		t.Fatal(h.Load().Port, ports)
+	}
+}
@@ -0,0 +1,15 @@
+package koanf
+
+import "time"
+
+//go:generate gogen cfggen --backend koanf
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		// listen address
+		"Addr":    ":8080",
+		"Timeout": time.Duration(time.Second), // request timeout
+		"Hosts":   []string{"a", "b"},
+		"Weights": map[string]int{"a": 1},
+		"MaxSize": uint64(1 << 20),
+	}
+}
@@ -0,0 +1,26 @@
+package koanf
+
+import (
+	"testing"
+
+	"github.com/knadh/koanf/v2"
+)
+
+func TestUnsigned(t *testing.T) {
+	k := koanf.New(".")
+	cfg, err := NewServerConfig("server", k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k.Set("server.maxsize", "18446744073709551615")
+	if err := cfg.RefreshValue(k); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxSize != 18446744073709551615 {
+		t.Fatal(cfg.MaxSize)
+	}
+	k.Set("server.maxsize", "-1")
+	if err := cfg.RefreshValue(k); err == nil {
+		t.Fatal("negative value accepted")
+	}
+}
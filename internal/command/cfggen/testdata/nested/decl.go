@@ -0,0 +1,24 @@
+package nested
+
+//go:generate gogen cfggen --backend viper
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Addr": ":8080",
+		// server.db.host, server.db.port
+		"DB": DBConfigDeclareWithDefault(),
+		// server.log.level, server.log.file.path
+		"Log": map[string]interface{}{
+			"Level": "info",
+			"File": map[string]interface{}{
+				"Path": "/tmp/log",
+			},
+		},
+	}
+}
+
+func DBConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Host": "127.0.0.1",
+		"Port": 3306,
+	}
+}
@@ -0,0 +1,12 @@
+package notify
+
+//go:generate gogen cfggen --backend env
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Addr":  ":8080",
+		"Hosts": []string{"a"},
+		"DB": map[string]interface{}{
+			"Port": 3306,
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	cfg, err := NewServerConfig("server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var addrs, ports []string
+	notified := 0
+	cfg.OnAddrChange(func(old, new string) { addrs = append(addrs, old+"->"+new) })
+	cfg.DB.OnPortChange(func(old, new int) { ports = append(ports, "changed") })
+	cfg.AddNotifyFunc(func(*ServerConfig) { notified++ })
+
+	t.Setenv("SERVER_ADDR", ":9090")
+	if err := cfg.RefreshValue(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(addrs, []string{":8080->:9090"}) || len(ports) != 0 || notified != 1 {
+		t.Fatal(addrs, ports, notified)
+	}
+	if !reflect.DeepEqual(cfg.Changes(), []string{"server.addr"}) {
+		t.Fatal(cfg.Changes())
+	}
+
+	// not changed, not notified
+	if err := cfg.RefreshValue(); err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 1 || notified != 1 || len(cfg.Changes()) != 0 {
+		t.Fatal(addrs, notified, cfg.Changes())
+	}
+
+	t.Setenv("SERVER_DB_PORT", "3307")
+	if err := cfg.RefreshValue(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ports) != 1 || len(addrs) != 1 || !reflect.DeepEqual(cfg.Changes(), []string{"server.db.port"}) {
+		t.Fatal(addrs, ports, cfg.Changes())
+	}
+}
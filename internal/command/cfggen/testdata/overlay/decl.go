@@ -0,0 +1,18 @@
+package overlay
+
+//go:generate gogen cfggen --backend viper --overlay
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Addr":  ":8080",
+		"Hosts": []string{"a"},
+		"DB":    DBConfigDeclareWithDefault(),
+	}
+}
+
+//go:generate gogen cfggen --backend viper --overlay
+func DBConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Host": "127.0.0.1",
+		"Port": 3306,
+	}
+}
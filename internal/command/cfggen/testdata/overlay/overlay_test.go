@@ -0,0 +1,45 @@
+package overlay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestOverlay(t *testing.T) {
+	vp := viper.New()
+	vp.SetConfigType("yaml")
+	if err := vp.ReadConfig(strings.NewReader("server:\n  db:\n    host: 10.0.0.1\n")); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := NewServerConfig("server", vp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	cfg.BindFlags(set)
+	if err := set.Parse([]string{"--server.addr=:7070"}); err != nil {
+		t.Fatal(err)
+	}
+	// prefix and lowercased key, upper case is also accepted
+	t.Setenv("server_db_port", "3307")
+	t.Setenv("SERVER_ADDR", ":9090")
+	if err := cfg.RefreshValue(vp); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Addr != ":7070" || cfg.DB.Port != 3307 || cfg.DB.Host != "10.0.0.1" {
+		t.Fatal(cfg.Addr, cfg.DB.Port, cfg.DB.Host)
+	}
+	for key, want := range map[string]string{
+		"server.addr":    "flag",
+		"server.db.port": "env",
+		"server.db.host": "config",
+		"server.hosts":   "default",
+	} {
+		if got := cfg.Source(key); got != want {
+			t.Errorf("source of %s: %s, want %s", key, got, want)
+		}
+	}
+}
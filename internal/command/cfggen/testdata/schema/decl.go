@@ -0,0 +1,18 @@
+package schema
+
+import "time"
+
+//go:generate gogen cfggen --backend env --schema json,yaml,toml
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		// listen address
+		"Addr": ":8080",
+		// gogen:range=1,65535
+		"Port":    8080,
+		"Timeout": time.Duration(time.Second),
+		"Hosts":   []string{"a", "b"},
+		"Start":   time.Time{},
+		// gogen:secret
+		"Token": "",
+	}
+}
@@ -0,0 +1,22 @@
+package secret
+
+//go:generate gogen cfggen --backend env
+func DBConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"User": "root",
+		// db password. eg: "file:/run/secrets/db_password"
+		// gogen:secret
+		"Password": "",
+		// gogen:secret
+		"Keys": []string(nil),
+	}
+}
+
+//go:generate gogen cfggen --backend viper
+func CacheConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Addr": "127.0.0.1:6379",
+		// gogen:secret
+		"Token": "dev-token",
+	}
+}
@@ -0,0 +1,19 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSecretDefault(t *testing.T) {
+	vp := viper.New()
+	NewDefaultCacheConfig("cache").SetDefaultValue(vp)
+	// key registered, secret default value not set
+	if !vp.IsSet("cache.token") || vp.GetString("cache.token") != "" {
+		t.Fatal(vp.AllSettings())
+	}
+	if vp.GetString("cache.addr") != "127.0.0.1:6379" {
+		t.Fatal(vp.AllSettings())
+	}
+}
@@ -0,0 +1,14 @@
+package source
+
+import "time"
+
+//go:generate gogen cfggen --backend source
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		// listen address
+		"Addr":    ":8080",
+		"Timeout": time.Duration(time.Second), // request timeout
+		"Hosts":   []string{"a", "b"},
+		"Weights": map[string]int{"a": 1},
+	}
+}
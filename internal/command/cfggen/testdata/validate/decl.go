@@ -0,0 +1,17 @@
+package validate
+
+//go:generate gogen cfggen --backend env
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		// gogen:range=1,65535
+		"Port": 8080,
+		// gogen:oneof=debug,release
+		"Mode": "debug",
+		// gogen:regex=^[a-z]+$
+		"Name": "server",
+		// gogen:required
+		"Hosts": []string{"a"},
+		// gogen:range=,10
+		"Tags": map[string]string(nil),
+	}
+}
@@ -0,0 +1,11 @@
+package wallefortest
+
+import "time"
+
+//go:generate gogen cfggen --backend walle --for-test
+func ServerConfigDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Addr":    ":8080",
+		"Timeout": time.Duration(time.Second),
+	}
+}
@@ -0,0 +1,20 @@
+package wallefortest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeCentra(t *testing.T) {
+	cfg := NewDefaultServerConfig("server")
+	cc := NewServerConfigFakeCentra()
+	cfg.SetDefaultValue(cc)
+	cc.Set("server.addr", ":9090")
+	cc.Set("server.timeout", 3*time.Second)
+	if err := cfg.RefreshValue(cc); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Addr != ":9090" || cfg.Timeout != 3*time.Second {
+		t.Fatal(cfg.Addr, cfg.Timeout)
+	}
+}
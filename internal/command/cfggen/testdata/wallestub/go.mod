@@ -0,0 +1,3 @@
+module github.com/walleframe/walle
+
+go 1.22
@@ -0,0 +1,35 @@
+// Package configcentra stub of github.com/walleframe/walle/services/configcentra,
+// only declare api used by generated code to compile it in tests.
+package configcentra
+
+import "time"
+
+// ConfigCentra config centra interface
+type ConfigCentra interface {
+	UseObject() bool
+	SetObject(key string, doc string, obj interface{})
+	GetObject(key string, obj interface{}) error
+	SetDefault(key string, doc string, value interface{})
+	GetInt(key string) (int, error)
+	GetInt32(key string) (int32, error)
+	GetInt64(key string) (int64, error)
+	GetUint(key string) (uint, error)
+	GetUint32(key string) (uint32, error)
+	GetUint64(key string) (uint64, error)
+	GetBool(key string) (bool, error)
+	GetFloat64(key string) (float64, error)
+	GetString(key string) (string, error)
+	GetDuration(key string) (time.Duration, error)
+	GetIntSlice(key string) ([]int, error)
+	GetStringSlice(key string) ([]string, error)
+	GetStringMapString(key string) (map[string]string, error)
+}
+
+// ConfigValue config value registered to config centra
+type ConfigValue interface {
+	SetDefaultValue(cc ConfigCentra)
+	RefreshValue(cc ConfigCentra) error
+}
+
+// RegisterConfig register config value
+func RegisterConfig(v ConfigValue) {}
@@ -1,17 +1,37 @@
 package cfggen
 
+// configTemplate common part of generated config, backend template define
+// "imports", "source-param", "source-arg" and "source" blocks, optional
+// "extra-imports", "extra-source" and "test-source" blocks. unused imports are removed by goimports.
+// "test-file" block is test helpers file.
 var configTemplate = `// Code generated by "gogen cfggen"; DO NOT EDIT.
 // Exec: gogen {{.Commands}} Version: {{.Version}}
 package {{.PackageName}}
 
 import (
+    "encoding/json"
+    "log/slog"
+    "os"
+    "reflect"
+    "regexp"
+    "strconv"
+    "strings"
+    "sync"
+    "sync/atomic"
     "time"
-    "github.com/spf13/viper"
-    "github.com/walleframe/walle/services/configcentra"
+    {{- if .Overlay }}
+    "github.com/spf13/pflag"
+    {{- end }}
+    {{- template "imports" . }}
+    {{- template "extra-imports" . }}
 )
 
 var _ = {{.FromFunc}}()
+{{ range $st := .Structs }}
+{{ template "config" $st }}
+{{ end }}
 
+{{- define "config" }}
 // {{.Name}} config generate by gogen cfggen.
 type {{.Name}} struct { {{- range $i,$f := .Fields }}
     {{Comment $f -}}
@@ -20,19 +40,29 @@ type {{.Name}} struct { {{- range $i,$f := .Fields }}
     prefix string
     // update ntf funcs
     ntfFuncs []func(*{{.Name}})
-}
-
-var _ configcentra.ConfigValue = (*{{.Name}})(nil)
-
-func New{{.Name}}(prefix string) *{{.Name}}{
-	if prefix == "" {
-		panic("config prefix invalid")
-	}
-    // new default config value
-    cfg := NewDefault{{.Name}}(prefix)
-    // register value to config centra
-    configcentra.RegisterConfig(cfg)
-    return cfg
+    // rejected update funcs
+    rejectFuncs []func(error)
+    // changed keys of last refresh
+    changes []string
+{{- if and .Secret (not .Child) }}
+    // secret resolver set by SetSecretResolver
+    resolver {{.Name}}SecretResolver
+{{- end }}
+{{- if .Overlay }}
+    // keys of this level read from config source in last refresh
+    read []string
+{{- end }}
+{{- if and .Overlay (not .Child) }}
+    // flags bind by BindFlags
+    flags *pflag.FlagSet
+    // effective source of keys which value not default
+    sources map[string]string
+    // value read from config source before overlay, used to reset overridden value
+    base *{{.Name}}
+{{- end }}
+{{- range $i,$f := .Fields}}{{ if not $f.Nested }}
+    on{{CamelCase $f.Name}}Change []func(old, new {{$f.Type}})
+{{- end }}{{ end }}
 }
 
 func NewDefault{{.Name}}(prefix string)*{{.Name}}{
@@ -43,38 +73,1384 @@ func NewDefault{{.Name}}(prefix string)*{{.Name}}{
     return cfg
 }
 
-// add notify func
+// add notify func, called when any value of this level or nested configs changed.
 func (cfg *{{.Name}}) AddNotifyFunc(f func(*{{.Name}})) {
     cfg.ntfFuncs = append(cfg.ntfFuncs, f)
 }
+{{ range $i,$f := .Fields}}{{ if not $f.Nested }}
+// On{{CamelCase $f.Name}}Change add func called when {{$f.Name}} changed.
+func (cfg *{{$.Name}}) On{{CamelCase $f.Name}}Change(f func(old, new {{$f.Type}})) {
+    cfg.on{{CamelCase $f.Name}}Change = append(cfg.on{{CamelCase $f.Name}}Change, f)
+}
+{{ end }}{{ end }}
+// Changes changed keys of last refresh, include nested configs. eg: "prefix.name"
+func (cfg *{{.Name}}) Changes() []string {
+    return cfg.changes
+}
+
+// diff record changed keys compare with old value, include nested configs.
+func (cfg *{{.Name}}) diff(old *{{.Name}}) []string {
+    cfg.changes = nil
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.changes = append(cfg.changes, cfg.{{$f.Name}}.diff(old.{{$f.Name}})...)
+{{- else if or (eq $f.Kind "number") (eq $f.Kind "string") }}
+    if cfg.{{$f.Name}} != old.{{$f.Name}} {
+        cfg.changes = append(cfg.changes, cfg.prefix + ".{{ToLower $f.Name}}")
+    }
+{{- else }}
+    if !reflect.DeepEqual(cfg.{{$f.Name}}, old.{{$f.Name}}) {
+        cfg.changes = append(cfg.changes, cfg.prefix + ".{{ToLower $f.Name}}")
+    }
+{{- end }}{{ end }}
+    return cfg.changes
+}
+
+// changed report key changed in last refresh
+func (cfg *{{.Name}}) changed(key string) bool {
+    for _, v := range cfg.changes {
+        if v == key {
+            return true
+        }
+    }
+    return false
+}
+
+// notify nested configs first, then changed field funcs and notify funcs of this level.
+// must call diff before notify.
+func (cfg *{{.Name}}) notify(old *{{.Name}}) {
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.{{$f.Name}}.notify(old.{{$f.Name}})
+{{- end }}{{ end }}
+{{- range $i,$f := .Fields}}{{ if not $f.Nested }}
+    if cfg.changed(cfg.prefix + ".{{ToLower $f.Name}}") {
+        for _, f := range cfg.on{{CamelCase $f.Name}}Change {
+            f(old.{{$f.Name}}, cfg.{{$f.Name}})
+        }
+    }
+{{- end }}{{ end }}
+    if len(cfg.changes) == 0 {
+        return
+    }
+    for _, ntf := range cfg.ntfFuncs {
+        ntf(cfg)
+    }
+}
+
+// AddRejectFunc add func called when update rejected, current value keep unchanged.
+func (cfg *{{.Name}}) AddRejectFunc(f func(error)) {
+    cfg.rejectFuncs = append(cfg.rejectFuncs, f)
+}
+
+// reject report rejected update
+func (cfg *{{.Name}}) reject(err error) error {
+    for _, f := range cfg.rejectFuncs {
+        f(err)
+    }
+    return err
+}
+{{ range $i,$f := .Fields}}{{ if $f.Regexp }}
+var regexp{{$.Name}}{{$f.Name}} = regexp.MustCompile({{Quote $f.Regexp}})
+{{ end }}{{ end }}
+// validate check value of this level and nested configs, then call Validate() if implemented.
+func (cfg *{{.Name}}) validate() error {
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    if err := cfg.{{$f.Name}}.validate(); err != nil {
+        return err
+    }
+{{- else }}
+{{- if $f.Required }}
+    if {{ if eq $f.Kind "number" }}cfg.{{$f.Name}} == 0{{ else if $f.Kind }}len(cfg.{{$f.Name}}) == 0{{ else }}reflect.ValueOf(cfg.{{$f.Name}}).IsZero(){{ end }} {
+        return fmt.Errorf("config %s.{{ToLower $f.Name}} required", cfg.prefix)
+    }
+{{- end }}
+{{- if or $f.RangeMin $f.RangeMax }}
+    if v := {{ if eq $f.Kind "number" }}cfg.{{$f.Name}}{{ else }}len(cfg.{{$f.Name}}){{ end }}; {{ if $f.RangeMin }}v < {{$f.RangeMin}}{{ end }}{{ if and $f.RangeMin $f.RangeMax }} || {{ end }}{{ if $f.RangeMax }}v > {{$f.RangeMax}}{{ end }} {
+        {{- if and $f.Secret (eq $f.Kind "number") }}
+        return fmt.Errorf("config %s.{{ToLower $f.Name}} out of range [%s, %s]", cfg.prefix, {{Quote $f.RangeMin}}, {{Quote $f.RangeMax}})
+        {{- else }}
+        return fmt.Errorf("config %s.{{ToLower $f.Name}} {{ if ne $f.Kind "number" }}length {{ end }}%v out of range [%s, %s]", cfg.prefix, v, {{Quote $f.RangeMin}}, {{Quote $f.RangeMax}})
+        {{- end }}
+    }
+{{- end }}
+{{- if $f.Regexp }}
+    if !regexp{{$.Name}}{{$f.Name}}.MatchString(string(cfg.{{$f.Name}})) {
+        {{- if $f.Secret }}
+        return fmt.Errorf("config %s.{{ToLower $f.Name}} not match %s", cfg.prefix, regexp{{$.Name}}{{$f.Name}})
+        {{- else }}
+        return fmt.Errorf("config %s.{{ToLower $f.Name}} %q not match %s", cfg.prefix, cfg.{{$f.Name}}, regexp{{$.Name}}{{$f.Name}})
+        {{- end }}
+    }
+{{- end }}
+{{- if $f.OneOf }}
+    switch cfg.{{$f.Name}} {
+    case {{ Join $f.OneOf ", " }}:
+    default:
+        {{- if $f.Secret }}
+        return fmt.Errorf("config %s.{{ToLower $f.Name}} not one of %s", cfg.prefix, {{ Quote (Join $f.OneOf ", ") }})
+        {{- else }}
+        return fmt.Errorf("config %s.{{ToLower $f.Name}} %v not one of %s", cfg.prefix, cfg.{{$f.Name}}, {{ Quote (Join $f.OneOf ", ") }})
+        {{- end }}
+    }
+{{- end }}
+{{- end }}{{ end }}
+    if v, ok := interface{}(cfg).(interface{ Validate() error }); ok {
+        if err := v.Validate(); err != nil {
+            return fmt.Errorf("config %s invalid: %w", cfg.prefix, err)
+        }
+    }
+    return nil
+}
+
+// assign copy value from other config, nested configs keep pointer.
+func (cfg *{{.Name}}) assign(from *{{.Name}}) {
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.{{$f.Name}}.assign(from.{{$f.Name}})
+{{- else }}
+    cfg.{{$f.Name}} = from.{{$f.Name}}
+{{- end }}{{ end }}
+{{- if and .Overlay (not .Child) }}
+    cfg.sources = from.sources
+    cfg.base = from.base
+{{- end }}
+}
+
+{{- if .Overlay }}
+{{ template "overlay" . }}
+{{ end }}
+{{- if .Secret }}
+{{ template "secret" . }}
+{{ end }}
+{{- if .ParseDurations }}
+// parseDurations parse duration list, JSON array or comma separated. eg: ["1s","2m"], 1s,2m
+func (cfg *{{.Name}}) parseDurations(s string) ([]time.Duration, error) {
+    var list []string
+    if strings.HasPrefix(strings.TrimSpace(s), "[") {
+        if err := json.Unmarshal([]byte(s), &list); err != nil {
+            return nil, err
+        }
+    } else if s != "" {
+        list = strings.Split(s, ",")
+    }
+    v := make([]time.Duration, 0, len(list))
+    for _, item := range list {
+        d, err := time.ParseDuration(strings.TrimSpace(item))
+        if err != nil {
+            return nil, err
+        }
+        v = append(v, d)
+    }
+    return v, nil
+}
+{{ end }}
+// clone copy config, nested configs are copied too.
+func (cfg *{{.Name}}) clone() *{{.Name}} {
+    c := *cfg
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    c.{{$f.Name}} = cfg.{{$f.Name}}.clone()
+{{- end }}{{ end }}
+    return &c
+}
+
+{{ template "source" . }}
+{{- template "extra-source" . }}
+{{- end }}
+
+{{- define "test-file" }}// Code generated by "gogen cfggen"; DO NOT EDIT.
+// Exec: gogen {{.Commands}} Version: {{.Version}}
+package {{.PackageName}}
+
+import (
+    "encoding"
+    "testing"
+    "time"
+    {{- template "imports" . }}
+)
+{{ range $st := .Structs }}
+{{ template "for-test" $st }}
+{{ end }}
+{{- end }}
+
+{{- define "extra-imports" }}{{ end }}
+{{- define "extra-source" }}{{ end }}
+{{- define "test-source" }}{{ end }}
+
+{{- define "for-test" }}
+{{- range $i,$f := .Fields}}{{ if not $f.Nested }}
+// With{{CamelCase $f.Name}}ForTest set {{$f.Name}} for test, notify funcs are called.
+// previous value is restored by t.Cleanup.
+func (cfg *{{$.Name}}) With{{CamelCase $f.Name}}ForTest(t testing.TB, v {{$f.Type}}) {
+    t.Helper()
+    previous := cfg.{{$f.Name}}
+    cfg.updateForTest(func(c *{{$.Name}}) { c.{{$f.Name}} = v })
+    t.Cleanup(func() {
+        cfg.updateForTest(func(c *{{$.Name}}) { c.{{$f.Name}} = previous })
+    })
+}
+{{ end }}{{ end }}
+{{- if and .Atomic (not .Child) }}
+// UpdateForTest update value for test, notify funcs are called.
+// previous value is restored by t.Cleanup.
+func (h *{{.Name}}Holder) UpdateForTest(t testing.TB, update func(cfg *{{.Name}})) {
+    t.Helper()
+    h.mutex.Lock()
+    defer h.mutex.Unlock()
+    previous := h.value.Load()
+    h.updateForTest(update)
+    t.Cleanup(func() {
+        h.mutex.Lock()
+        defer h.mutex.Unlock()
+        h.updateForTest(func(cfg *{{.Name}}) { cfg.assign(previous) })
+    })
+}
+
+// updateForTest store updated copy of current value and notify. must hold mutex.
+func (h *{{.Name}}Holder) updateForTest(update func(cfg *{{.Name}})) {
+    old := h.value.Load()
+    cfg := old.clone()
+    update(cfg)
+    cfg.diff(old)
+    h.value.Store(cfg)
+    h.notify(old, cfg)
+}
+{{ end }}
+// updateForTest update value and notify changed
+func (cfg *{{.Name}}) updateForTest(update func(c *{{.Name}})) {
+    old := cfg.clone()
+    update(cfg)
+    cfg.diff(old)
+    cfg.notify(old)
+}
+{{- if not .Child }}
+{{ template "test-source" . }}
+{{- end }}
+{{- end }}
+
+{{- define "env-lookup" -}}
+func(key string) (string, bool) {
+        // prefix and lowercased key, eg: server_db_port. upper case SERVER_DB_PORT is also accepted
+        name := strings.NewReplacer(".", "_", "-", "_").Replace(key)
+        if v, ok := os.LookupEnv(name); ok {
+            return v, true
+        }
+        return os.LookupEnv(strings.ToUpper(name))
+    }
+{{- end }}
+
+{{- define "overlay" }}
+{{- if not .Child }}
+// BindFlags register flag of each key to set, call it before parse flags. eg: --prefix.name=value
+// flag value override env and config source since next refresh.
+func (cfg *{{.Name}}) BindFlags(set *pflag.FlagSet) {
+    cfg.flags = set
+    cfg.bindFlags(set)
+}
+
+// Source effective source of key value. "flag", "env", "config" or "default"
+func (cfg *{{.Name}}) Source(key string) string {
+    if v, ok := cfg.sources[key]; ok {
+        return v
+    }
+    return "default"
+}
+
+// Sources effective source of keys which value not default.
+func (cfg *{{.Name}}) Sources() map[string]string {
+    return cfg.sources
+}
+
+// overlay override value by env and flag, record effective source of keys.
+// precedence: flag > env > config source > default.
+// env name is key with "." and "-" replaced by "_", eg: server_db_port, upper case SERVER_DB_PORT is also accepted.
+func (cfg *{{.Name}}) overlay() error {
+    cfg.base = cfg.clone()
+    cfg.base.base = nil
+    sources := make(map[string]string)
+    cfg.configSources(sources)
+    if err := cfg.overlayValue({{ template "env-lookup" }}, sources, "env"); err != nil {
+        return err
+    }
+    if cfg.flags != nil {
+        lookup := func(key string) (string, bool) {
+            if f := cfg.flags.Lookup(key); f != nil && f.Changed {
+                return f.Value.String(), true
+            }
+            return "", false
+        }
+        if err := cfg.overlayValue(lookup, sources, "flag"); err != nil {
+            return err
+        }
+    }
+    cfg.sources = sources
+    return nil
+}
+
+// resetOverlay restore value overridden by env and flag in last overlay, call it before refresh.
+// keys removed from env and flag fall back to config source.
+func (cfg *{{.Name}}) resetOverlay() {
+    if cfg.base != nil {
+        cfg.resetValue(cfg.base, cfg.sources)
+    }
+}
+{{ end }}
+// configSources record keys of this level and nested configs read from config source
+func (cfg *{{.Name}}) configSources(sources map[string]string) {
+    for _, key := range cfg.read {
+        sources[key] = "config"
+    }
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.{{$f.Name}}.configSources(sources)
+{{- end }}{{ end }}
+}
+
+// resetValue restore value of this level and nested configs overridden by env and flag
+func (cfg *{{.Name}}) resetValue(base *{{.Name}}, sources map[string]string) {
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.{{$f.Name}}.resetValue(base.{{$f.Name}}, sources)
+{{- else }}
+    switch sources[cfg.prefix + ".{{ToLower $f.Name}}"] {
+    case "env", "flag":
+        cfg.{{$f.Name}} = base.{{$f.Name}}
+    }
+{{- end }}{{ end }}
+}
+
+// bindFlags register flags of this level and nested configs
+func (cfg *{{.Name}}) bindFlags(set *pflag.FlagSet) {
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.{{$f.Name}}.bindFlags(set)
+{{- else }}
+    set.String(cfg.prefix + ".{{ToLower $f.Name}}", "", {{ Quote (OneRow $f.Doc) }})
+{{- end }}{{ end }}
+}
+
+// overlayValue override value of this level and nested configs by lookup
+func (cfg *{{.Name}}) overlayValue(lookup func(key string) (string, bool), sources map[string]string, source string) error {
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    if err := cfg.{{$f.Name}}.overlayValue(lookup, sources, source); err != nil {
+        return err
+    }
+{{- else }}
+    if s, ok := lookup(cfg.prefix + ".{{ToLower $f.Name}}"); ok {
+		{{- template "decode" $f.Overlay }}
+        sources[cfg.prefix + ".{{ToLower $f.Name}}"] = source
+    }
+{{- end }}{{ end }}
+    return nil
+}
+{{- end }}
+
+{{- define "secret" }}
+{{- if not .Child }}
+// {{.Name}}SecretResolver resolve value of secret fields.
+type {{.Name}}SecretResolver interface {
+    // Resolve return secret of reference, value which is not reference should be returned as it is.
+    Resolve(ref string) (string, error)
+}
+
+// {{.Name}}FileSecretResolver read secret from file of reference "file:<path>", trailing newline is trimmed.
+type {{.Name}}FileSecretResolver struct{}
+
+func ({{.Name}}FileSecretResolver) Resolve(ref string) (string, error) {
+    path := strings.TrimPrefix(ref, "file:")
+    if path == ref {
+        return ref, nil
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return "", err
+    }
+    return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// SetSecretResolver set resolver of secret fields, use {{.Name}}FileSecretResolver if not set.
+// secret resolved since next refresh.
+func (cfg *{{.Name}}) SetSecretResolver(r {{.Name}}SecretResolver) {
+    cfg.resolver = r
+}
+
+// secretResolver resolver of secret fields
+func (cfg *{{.Name}}) secretResolver() {{.Name}}SecretResolver {
+    if cfg.resolver != nil {
+        return cfg.resolver
+    }
+    return {{.Name}}FileSecretResolver{}
+}
+{{ end }}
+// resolveSecrets resolve string secret fields of this level and nested configs.
+func (cfg *{{.Name}}) resolveSecrets(resolve func(ref string) (string, error)) error {
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    if err := cfg.{{$f.Name}}.resolveSecrets(resolve); err != nil {
+        return err
+    }
+{{- else if and $f.Secret (eq $f.Kind "string") }}
+    if v, err := resolve(string(cfg.{{$f.Name}})); err != nil {
+        return fmt.Errorf("config %s.{{ToLower $f.Name}} resolve secret failed: %w", cfg.prefix, err)
+    } else {
+        cfg.{{$f.Name}} = {{$f.Type}}(v)
+    }
+{{- end }}{{ end }}
+    return nil
+}
+
+// String format config, secret fields are masked.
+func (cfg *{{.Name}}) String() string {
+    return fmt.Sprintf("{
+        {{- range $i,$f := .Fields}}{{ if $i }} {{ end }}{{ToLower $f.Name}}:{{ if $f.Secret }}******{{ else }}%v{{ end }}{{ end -}}
+    }"
+    {{- range $i,$f := .Fields}}{{ if not $f.Secret }}, cfg.{{$f.Name}}{{ end }}{{ end }})
+}
+
+{{- if .SecretFields }}
+
+// MarshalJSON implements json.Marshaler, secret fields which are not empty are masked.
+func (cfg {{.Name}}) MarshalJSON() ([]byte, error) {
+    // plain has no method, marshal it as usual
+    type plain {{.Name}}
+    return json.Marshal(struct {
+        plain
+{{- range $i,$f := .SecretFields }}
+        {{$f.Name}} interface{} {{Tag "json" $f.Name "omitempty" }}
+{{- end }}
+    }{
+        plain: plain(cfg),
+{{- range $i,$f := .SecretFields }}
+        {{$f.Name}}: mask{{$.Name}}Secret(!reflect.ValueOf(cfg.{{$f.Name}}).IsZero()),
+{{- end }}
+    })
+}
+
+// mask{{.Name}}Secret masked value of secret, nil if secret is empty
+func mask{{.Name}}Secret(set bool) interface{} {
+    if set {
+        return "******"
+    }
+    return nil
+}
+{{- end }}
+
+// LogValue implements slog.LogValuer, secret fields are masked.
+func (cfg *{{.Name}}) LogValue() slog.Value {
+    return slog.GroupValue(
+{{- range $i,$f := .Fields}}
+    {{- if $f.Secret }}
+        slog.String("{{ToLower $f.Name}}", "******"),
+    {{- else }}
+        slog.Any("{{ToLower $f.Name}}", cfg.{{$f.Name}}),
+    {{- end }}
+{{- end }}
+    )
+}
+{{- end }}
+
+{{- define "refresh-value" }}
+// update is all-or-nothing, current value keep unchanged if read or validate failed.
+func (cfg *{{.Name}}) RefreshValue({{ template "source-param" . }}) error {
+    c := cfg.clone()
+{{- if and .Overlay (not .Child) }}
+    c.resetOverlay()
+{{- end }}
+    if err := c.refresh({{ template "source-arg" . }}); err != nil {
+        return cfg.reject(err)
+    }
+{{- if and .Overlay (not .Child) }}
+    if err := c.overlay(); err != nil {
+        return cfg.reject(err)
+    }
+{{- end }}
+{{- if and .Secret (not .Child) }}
+    if err := c.resolveSecrets(c.secretResolver().Resolve); err != nil {
+        return cfg.reject(err)
+    }
+{{- end }}
+    if err := c.validate(); err != nil {
+        return cfg.reject(err)
+    }
+    old := cfg.clone()
+    cfg.assign(c)
+    cfg.diff(old)
+    // notify update
+    cfg.notify(old)
+    return nil
+}
+{{- end }}
+
+{{- define "holder" }}
+// {{.Name}}Holder hold {{.Name}} by atomic pointer, safe for concurrent use.
+// refresh read into a copy then swap it in, value returned by Load must not be modified,
+// register notify funcs by methods of holder instead of the snapshot.
+type {{.Name}}Holder struct {
+    value    atomic.Pointer[{{.Name}}]
+    mutex    sync.Mutex
+    ntfFuncs []func(old, new *{{.Name}})
+    // change funcs of field, key relative to prefix => funcs
+    fieldFuncs map[string][]func(old, new *{{.Name}})
+    // rejected update funcs
+    rejectFuncs []func(error)
+}
+
+// Load get current config snapshot
+func (h *{{.Name}}Holder) Load() *{{.Name}} {
+    return h.value.Load()
+}
+
+// AddNotifyFunc add notify func, called after changed value swapped in.
+func (h *{{.Name}}Holder) AddNotifyFunc(f func(old, new *{{.Name}})) {
+    h.mutex.Lock()
+    defer h.mutex.Unlock()
+    h.ntfFuncs = append(h.ntfFuncs, f)
+}
+
+// addFieldFunc add change func of field key
+func (h *{{.Name}}Holder) addFieldFunc(key string, f func(old, new *{{.Name}})) {
+    h.mutex.Lock()
+    defer h.mutex.Unlock()
+    if h.fieldFuncs == nil {
+        h.fieldFuncs = make(map[string][]func(old, new *{{.Name}}))
+    }
+    h.fieldFuncs[key] = append(h.fieldFuncs[key], f)
+}
+{{ range $f := .LeafFields }}
+// On{{$f.Method}}Change add func called after changed {{$f.Path}} swapped in.
+func (h *{{$.Name}}Holder) On{{$f.Method}}Change(f func(old, new {{$f.Type}})) {
+    h.addFieldFunc("{{$f.Key}}", func(old, new *{{$.Name}}) { f(old.{{$f.Path}}, new.{{$f.Path}}) })
+}
+{{ end }}
+// notify call change funcs of changed keys, then notify funcs. nothing called if not changed.
+// must hold mutex.
+func (h *{{.Name}}Holder) notify(old, cfg *{{.Name}}) {
+    if len(cfg.changes) == 0 {
+        return
+    }
+    for _, key := range cfg.changes {
+        for _, f := range h.fieldFuncs[strings.TrimPrefix(key, cfg.prefix + ".")] {
+            f(old, cfg)
+        }
+    }
+    for _, ntf := range h.ntfFuncs {
+        ntf(old, cfg)
+    }
+}
+
+{{ if .Secret -}}
+// SetSecretResolver set resolver of secret fields, see {{.Name}}.SetSecretResolver
+// published value is not modified, a copy with resolver is swapped in.
+func (h *{{.Name}}Holder) SetSecretResolver(r {{.Name}}SecretResolver) {
+    h.mutex.Lock()
+    defer h.mutex.Unlock()
+    cfg := h.value.Load().clone()
+    cfg.SetSecretResolver(r)
+    h.value.Store(cfg)
+}
+
+{{ end -}}
+{{ if .Overlay -}}
+// BindFlags register flag of each key to set, see {{.Name}}.BindFlags
+// published value is not modified, a copy bound flags is swapped in.
+func (h *{{.Name}}Holder) BindFlags(set *pflag.FlagSet) {
+    h.mutex.Lock()
+    defer h.mutex.Unlock()
+    cfg := h.value.Load().clone()
+    cfg.BindFlags(set)
+    h.value.Store(cfg)
+}
+
+{{ end -}}
+// AddRejectFunc add func called when update rejected, current value keep unchanged.
+func (h *{{.Name}}Holder) AddRejectFunc(f func(error)) {
+    h.mutex.Lock()
+    defer h.mutex.Unlock()
+    h.rejectFuncs = append(h.rejectFuncs, f)
+}
+
+// reject report rejected update
+func (h *{{.Name}}Holder) reject(err error) error {
+    for _, f := range h.rejectFuncs {
+        f(err)
+    }
+    return err
+}
+
+// RefreshValue read config into a copy of current value, then swap it in.
+// current value keep unchanged if read or validate failed.
+func (h *{{.Name}}Holder) RefreshValue({{ template "source-param" . }}) error {
+    h.mutex.Lock()
+    defer h.mutex.Unlock()
+    old := h.value.Load()
+    cfg := old.clone()
+{{- if .Overlay }}
+    cfg.resetOverlay()
+{{- end }}
+    if err := cfg.refresh({{ template "source-arg" . }}); err != nil {
+        return h.reject(err)
+    }
+{{- if .Overlay }}
+    if err := cfg.overlay(); err != nil {
+        return h.reject(err)
+    }
+{{- end }}
+{{- if .Secret }}
+    if err := cfg.resolveSecrets(cfg.secretResolver().Resolve); err != nil {
+        return h.reject(err)
+    }
+{{- end }}
+    if err := cfg.validate(); err != nil {
+        return h.reject(err)
+    }
+    // store even if not changed, overlay sources and read keys are refreshed.
+    cfg.diff(old)
+    h.value.Store(cfg)
+    // notify update
+    h.notify(old, cfg)
+    return nil
+}
+{{- end }}
+
+{{- define "decode-error" }}
+        {{- if .Secret }}
+            // error may contain value of secret
+            return fmt.Errorf("config %s.{{ToLower .Name}} invalid", cfg.prefix)
+        {{- else }}
+            return fmt.Errorf("config %s.{{ToLower .Name}} invalid: %w", cfg.prefix, err)
+        {{- end }}
+{{- end }}
+
+{{- define "decode" }}
+{{- if eq .Decode "text" }}
+    {{- if eq .Getter "s" }}
+    {{- else if .GetterErr }}
+        s, err := {{.Getter}}
+        if err != nil {
+            {{- template "decode-error" . }}
+        }
+    {{- else }}
+        s := {{.Getter}}
+    {{- end }}
+        var v {{.Type}}
+        if err := v.UnmarshalText([]byte(s)); err != nil {
+            {{- template "decode-error" . }}
+        }
+        cfg.{{.Name}} = v
+{{- else if eq .Decode "generic" }}
+        var v {{.Type}}
+        if err := {{.Getter}}; err != nil {
+            {{- template "decode-error" . }}
+        }
+        cfg.{{.Name}} = v
+{{- else if .GetterErr }}
+        v, err := {{.Getter}}
+        if err != nil {
+            {{- template "decode-error" . }}
+        }
+        cfg.{{.Name}} = ({{.Type}})(v)
+{{- else }}
+        cfg.{{.Name}} = ({{.Type}})({{.Getter}})
+{{- end }}
+{{- end }}
+`
+
+// walleTemplate walle configcentra backend
+var walleTemplate = `
+{{- define "imports" }}
+    "fmt"
+    "github.com/spf13/viper"
+    "github.com/walleframe/walle/services/configcentra"
+{{- end }}
+
+{{- define "source-param" }}cc configcentra.ConfigCentra{{ end }}
+
+{{- define "test-source" }}
+// {{.Name}}FakeCentra in-memory configcentra.ConfigCentra for test, value is stored in viper.
+type {{.Name}}FakeCentra struct {
+    vp *viper.Viper
+}
+
+var _ configcentra.ConfigCentra = (*{{.Name}}FakeCentra)(nil)
+
+// New{{.Name}}FakeCentra new in-memory config centra for test
+func New{{.Name}}FakeCentra() *{{.Name}}FakeCentra {
+    return &{{.Name}}FakeCentra{vp: viper.New()}
+}
+
+// Set set value of key. eg: "prefix.name"
+func (f *{{.Name}}FakeCentra) Set(key string, value interface{}) {
+    f.vp.Set(key, f.value(value))
+}
+
+func (f *{{.Name}}FakeCentra) UseObject() bool {
+    return false
+}
+
+func (f *{{.Name}}FakeCentra) SetObject(key string, doc string, obj interface{}) {
+}
+
+func (f *{{.Name}}FakeCentra) GetObject(key string, obj interface{}) error {
+    return f.vp.UnmarshalKey(key, obj)
+}
+
+func (f *{{.Name}}FakeCentra) SetDefault(key string, doc string, value interface{}) {
+    f.vp.SetDefault(key, f.value(value))
+}
+
+func (f *{{.Name}}FakeCentra) GetInt(key string) (int, error) {
+    return f.vp.GetInt(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetInt32(key string) (int32, error) {
+    return f.vp.GetInt32(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetInt64(key string) (int64, error) {
+    return f.vp.GetInt64(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetUint(key string) (uint, error) {
+    return f.vp.GetUint(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetUint32(key string) (uint32, error) {
+    return f.vp.GetUint32(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetUint64(key string) (uint64, error) {
+    return f.vp.GetUint64(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetBool(key string) (bool, error) {
+    return f.vp.GetBool(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetFloat64(key string) (float64, error) {
+    return f.vp.GetFloat64(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetString(key string) (string, error) {
+    return f.vp.GetString(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetDuration(key string) (time.Duration, error) {
+    return f.vp.GetDuration(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetIntSlice(key string) ([]int, error) {
+    return f.vp.GetIntSlice(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetStringSlice(key string) ([]string, error) {
+    return f.vp.GetStringSlice(key), nil
+}
+
+func (f *{{.Name}}FakeCentra) GetStringMapString(key string) (map[string]string, error) {
+    return f.vp.GetStringMapString(key), nil
+}
+
+// value text decoded value is stored as text
+func (f *{{.Name}}FakeCentra) value(value interface{}) interface{} {
+    if m, ok := value.(encoding.TextMarshaler); ok {
+        if text, err := m.MarshalText(); err == nil {
+            return string(text)
+        }
+    }
+    return value
+}
+{{- end }}
+{{- define "source-arg" }}cc{{ end }}
 
+{{- define "source" -}}
+var _ configcentra.ConfigValue = (*{{.Name}})(nil)
+{{ if not .Child }}
+func New{{.Name}}(prefix string) *{{.Name}}{
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    // new default config value
+    cfg := NewDefault{{.Name}}(prefix)
+    // register value to config centra
+    configcentra.RegisterConfig(cfg)
+    return cfg
+}
+{{ end }}
 // impl configcentra.ConfigValue
 func (cfg *{{.Name}}) SetDefaultValue(cc configcentra.ConfigCentra) {
 	if cc.UseObject() {
+		{{- if .Secret }}
+		// secret fields are cleared
+		obj := cfg.clone()
+		obj.redact()
+		cc.SetObject(cfg.prefix, "{{Doc .Document .Comment}}", obj)
+		{{- else }}
 		cc.SetObject(cfg.prefix, "{{Doc .Document .Comment}}", cfg)
-		return 
+		{{- end }}
+		return
 	}
-{{- range $i,$f := .Fields}}
-    cc.SetDefault(cfg.prefix + ".{{ToLower $f.Name}}", "{{OneRow $f.Doc}}", cfg.{{$f.Name}}) {{- end }} 
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.{{$f.Name}}.SetDefaultValue(cc)
+{{- else if $f.Secret }}
+    // secret value is not set, register key with zero value
+    cc.SetDefault(cfg.prefix + ".{{ToLower $f.Name}}", "{{OneRow $f.Doc}}", *new({{$f.Type}}))
+{{- else }}
+    cc.SetDefault(cfg.prefix + ".{{ToLower $f.Name}}", "{{OneRow $f.Doc}}", cfg.{{$f.Name}})
+{{- end }}{{ end }}
 }
+{{- if .Overlay }}
 
-// impl configcentra.ConfigValue
-func (cfg *{{.Name}}) RefreshValue(cc configcentra.ConfigCentra) error {
-    if cc.UseObject()  {
+// readAll mark all keys of this level and nested configs read from config source,
+// config centra can not report whether key is set.
+func (cfg *{{.Name}}) readAll() {
+    cfg.read = []string{ {{- range $i,$f := .Fields}}{{ if not $f.Nested }}
+        cfg.prefix + ".{{ToLower $f.Name}}",{{ end }}{{ end }}
+    }
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.{{$f.Name}}.readAll()
+{{- end }}{{ end }}
+}
+{{- end }}
+{{- if .Secret }}
+
+// redact clear secret fields of this level and nested configs
+func (cfg *{{.Name}}) redact() {
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.{{$f.Name}}.redact()
+{{- else if $f.Secret }}
+    cfg.{{$f.Name}} = *new({{$f.Type}})
+{{- end }}{{ end }}
+}
+{{- end }}
+
+// impl configcentra.ConfigValue{{- template "refresh-value" . }}
+
+// refresh read value of this level and nested configs.
+func (cfg *{{.Name}}) refresh(cc configcentra.ConfigCentra) error {
+{{- if .Overlay }}
+    cfg.readAll()
+{{- end }}
+    if cc.UseObject() {
 		return cc.GetObject(cfg.prefix, cfg)
-    } {{- range $i,$f := .Fields}}
-    {
-		v,err := cc.{{$f.GetMethod}}(cfg.prefix + ".{{ToLower $f.Name}}")
-		if err != nil {
-			return err
-		}
-		cfg.{{$f.Name}} = ({{$f.Type}})(v)
-	} {{- end }}
-    // notify update
-    for _, ntf := range cfg.ntfFuncs {
-        ntf(cfg)
     }
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    if err := cfg.{{$f.Name}}.refresh(cc); err != nil {
+        return err
+    }
+{{- else }}
+    {
+		key := cfg.prefix + ".{{ToLower $f.Name}}"
+		{{- template "decode" $f }}
+	}
+{{- end }}{{ end }}
 	return nil
 }
+{{- if .Atomic }}
+
+var _ configcentra.ConfigValue = (*{{.Name}}Holder)(nil)
+
+// New{{.Name}}Holder new holder with default value, register it to config centra.
+func New{{.Name}}Holder(prefix string) *{{.Name}}Holder {
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    h := &{{.Name}}Holder{}
+    h.value.Store(NewDefault{{.Name}}(prefix))
+    // register holder to config centra
+    configcentra.RegisterConfig(h)
+    return h
+}
+
+// impl configcentra.ConfigValue
+func (h *{{.Name}}Holder) SetDefaultValue(cc configcentra.ConfigCentra) {
+    h.Load().SetDefaultValue(cc)
+}
+{{ template "holder" . }}
+{{- end }}
+{{- end }}
+`
+
+// viperTemplate spf13/viper backend
+var viperTemplate = `
+{{- define "imports" }}
+    "fmt"
+    "github.com/spf13/viper"
+{{- end }}
+
+{{- define "source-param" }}vp *viper.Viper{{ end }}
+{{- define "source-arg" }}vp{{ end }}
+
+{{- define "source" -}}
+// New{{.Name}} new config with default value, then read from viper.
+func New{{.Name}}(prefix string, vp *viper.Viper) (*{{.Name}}, error) {
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    cfg := NewDefault{{.Name}}(prefix)
+    cfg.SetDefaultValue(vp)
+    if err := cfg.RefreshValue(vp); err != nil {
+        return nil, err
+    }
+    return cfg, nil
+}
+
+// SetDefaultValue set default value to viper
+func (cfg *{{.Name}}) SetDefaultValue(vp *viper.Viper) {
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    cfg.{{$f.Name}}.SetDefaultValue(vp)
+{{- else if $f.Secret }}
+    // secret value is not set, register key with zero value
+    vp.SetDefault(cfg.prefix + ".{{ToLower $f.Name}}", *new({{$f.Type}}))
+{{- else }}
+    vp.SetDefault(cfg.prefix + ".{{ToLower $f.Name}}", cfg.{{$f.Name}})
+{{- end }}{{ end }}
+}
+
+// RefreshValue read config from viper, keys not set keep current value.{{- template "refresh-value" . }}
+
+// refresh read value of this level and nested configs.
+func (cfg *{{.Name}}) refresh(vp *viper.Viper) error {
+{{- if .Overlay }}
+    cfg.read = nil
+{{- end }}
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    if err := cfg.{{$f.Name}}.refresh(vp); err != nil {
+        return err
+    }
+{{- else }}
+    if key := cfg.prefix + ".{{ToLower $f.Name}}"; vp.IsSet(key) {
+    {{- if $.Overlay }}
+        // IsSet report default value too
+        if vp.InConfig(key) {
+            cfg.read = append(cfg.read, key)
+        }
+    {{- end }}
+	{{- if eq $f.Decode "getter" }}
+		{{- template "decode" $f }}
+	{{- else }}
+        // default value or value set by program
+        if v, ok := vp.Get(key).({{$f.Type}}); ok {
+            cfg.{{$f.Name}} = v
+        } else {
+		{{- template "decode" $f }}
+        }
+	{{- end }}
+    }
+{{- end }}{{ end }}
+    return nil
+}
+{{- if .Atomic }}
+
+// New{{.Name}}Holder new holder with default value, then read from source.
+func New{{.Name}}Holder(prefix string, {{ template "source-param" . }}) (*{{.Name}}Holder, error) {
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    h := &{{.Name}}Holder{}
+    cfg := NewDefault{{.Name}}(prefix)
+    cfg.SetDefaultValue(vp)
+    if err := cfg.refresh({{ template "source-arg" . }}); err != nil {
+        return nil, err
+    }
+{{- if .Overlay }}
+    if err := cfg.overlay(); err != nil {
+        return nil, err
+    }
+{{- end }}
+{{- if .Secret }}
+    if err := cfg.resolveSecrets(cfg.secretResolver().Resolve); err != nil {
+        return nil, err
+    }
+{{- end }}
+    if err := cfg.validate(); err != nil {
+        return nil, err
+    }
+    h.value.Store(cfg)
+    return h, nil
+}
+{{ template "holder" . }}
+{{- end }}
+{{- end }}
+`
+
+// koanfTemplate knadh/koanf backend
+var koanfTemplate = `
+{{- define "imports" }}
+    "fmt"
+    "github.com/knadh/koanf/v2"
+{{- end }}
+
+{{- define "source-param" }}k *koanf.Koanf{{ end }}
+{{- define "source-arg" }}k{{ end }}
+
+{{- define "source" -}}
+// New{{.Name}} new config with default value, then read from koanf.
+func New{{.Name}}(prefix string, k *koanf.Koanf) (*{{.Name}}, error) {
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    cfg := NewDefault{{.Name}}(prefix)
+    if err := cfg.RefreshValue(k); err != nil {
+        return nil, err
+    }
+    return cfg, nil
+}
 
+// RefreshValue read config from koanf, keys not exists keep current value.{{- template "refresh-value" . }}
+
+// refresh read value of this level and nested configs.
+func (cfg *{{.Name}}) refresh(k *koanf.Koanf) error {
+{{- if .Overlay }}
+    cfg.read = nil
+{{- end }}
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    if err := cfg.{{$f.Name}}.refresh(k); err != nil {
+        return err
+    }
+{{- else }}
+    if key := cfg.prefix + ".{{ToLower $f.Name}}"; k.Exists(key) {
+    {{- if $.Overlay }}
+        cfg.read = append(cfg.read, key)
+    {{- end }}
+		{{- template "decode" $f }}
+    }
+{{- end }}{{ end }}
+    return nil
+}
+{{- if .Atomic }}
+
+// New{{.Name}}Holder new holder with default value, then read from source.
+func New{{.Name}}Holder(prefix string, {{ template "source-param" . }}) (*{{.Name}}Holder, error) {
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    h := &{{.Name}}Holder{}
+    cfg := NewDefault{{.Name}}(prefix)
+    if err := cfg.refresh({{ template "source-arg" . }}); err != nil {
+        return nil, err
+    }
+{{- if .Overlay }}
+    if err := cfg.overlay(); err != nil {
+        return nil, err
+    }
+{{- end }}
+{{- if .Secret }}
+    if err := cfg.resolveSecrets(cfg.secretResolver().Resolve); err != nil {
+        return nil, err
+    }
+{{- end }}
+    if err := cfg.validate(); err != nil {
+        return nil, err
+    }
+    h.value.Store(cfg)
+    return h, nil
+}
+{{ template "holder" . }}
+{{- end }}
+{{- end }}
+`
+
+// stringFieldsTemplate parse fields from string value, lookup by lookup func.
+var stringFieldsTemplate = `
+{{- define "string-fields" }}
+{{- if .Overlay }}
+    cfg.read = nil
+{{- end }}
+{{- range $i,$f := .Fields}}{{ if $f.Nested }}
+    if err := cfg.{{$f.Name}}.refresh(lookup); err != nil {
+        return err
+    }
+{{- else }}
+    if s, ok := lookup(cfg.prefix + ".{{ToLower $f.Name}}"); ok {
+    {{- if $.Overlay }}
+        cfg.read = append(cfg.read, cfg.prefix + ".{{ToLower $f.Name}}")
+    {{- end }}
+		{{- template "decode" $f }}
+    }
+{{- end }}{{ end }}
+    return nil
+{{- end }}
+`
+
+// envTemplate environment variables backend
+var envTemplate = stringFieldsTemplate + `
+{{- define "imports" }}
+    "fmt"
+{{- end }}
+
+{{- define "source-param" }}{{ end }}
+{{- define "source-arg" }}{{ template "env-lookup" }}{{ end }}
+
+{{- define "source" -}}
+// New{{.Name}} new config with default value, then read from environment variables.
+func New{{.Name}}(prefix string) (*{{.Name}}, error) {
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    cfg := NewDefault{{.Name}}(prefix)
+    if err := cfg.RefreshValue(); err != nil {
+        return nil, err
+    }
+    return cfg, nil
+}
+
+// RefreshValue read config from environment variables, variables not set keep current value.
+// key "prefix.name" read from variable PREFIX_NAME.{{- template "refresh-value" . }}
+
+// refresh read value of this level and nested configs.
+func (cfg *{{.Name}}) refresh(lookup func(key string) (string, bool)) error {
+{{- template "string-fields" . }}
+}
+{{- if .Atomic }}
+
+// New{{.Name}}Holder new holder with default value, then read from source.
+func New{{.Name}}Holder(prefix string, {{ template "source-param" . }}) (*{{.Name}}Holder, error) {
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    h := &{{.Name}}Holder{}
+    cfg := NewDefault{{.Name}}(prefix)
+    if err := cfg.refresh({{ template "source-arg" . }}); err != nil {
+        return nil, err
+    }
+{{- if .Overlay }}
+    if err := cfg.overlay(); err != nil {
+        return nil, err
+    }
+{{- end }}
+{{- if .Secret }}
+    if err := cfg.resolveSecrets(cfg.secretResolver().Resolve); err != nil {
+        return nil, err
+    }
+{{- end }}
+    if err := cfg.validate(); err != nil {
+        return nil, err
+    }
+    h.value.Store(cfg)
+    return h, nil
+}
+{{ template "holder" . }}
+{{- end }}
+{{- end }}
+`
+
+// sourceTemplate user implemented source backend
+var sourceTemplate = stringFieldsTemplate + `
+{{- define "imports" }}
+    "fmt"
+{{- end }}
+
+{{- define "source-param" }}src {{.Name}}Source{{ end }}
+
+{{- define "test-source" }}
+// {{.Name}}MapSource in-memory {{.Name}}Source for test
+type {{.Name}}MapSource map[string]string
+
+func (m {{.Name}}MapSource) Lookup(key string) (string, bool) {
+    v, ok := m[key]
+    return v, ok
+}
+{{- end }}
+{{- define "source-arg" }}src.Lookup{{ end }}
+
+{{- define "source" -}}
+{{- if not .Child }}
+// {{.Name}}Source config source of {{.Name}}
+type {{.Name}}Source interface {
+    // Lookup get config string value by key. eg: "prefix.name"
+    Lookup(key string) (value string, ok bool)
+}
+
+// New{{.Name}} new config with default value, then read from source.
+func New{{.Name}}(prefix string, src {{.Name}}Source) (*{{.Name}}, error) {
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    cfg := NewDefault{{.Name}}(prefix)
+    if err := cfg.RefreshValue(src); err != nil {
+        return nil, err
+    }
+    return cfg, nil
+}
+
+// RefreshValue read config from source, keys not found keep current value.{{- template "refresh-value" . }}
+{{ end }}
+// refresh read value of this level and nested configs.
+func (cfg *{{.Name}}) refresh(lookup func(key string) (string, bool)) error {
+{{- template "string-fields" . }}
+}
+{{- if .Atomic }}
+
+// New{{.Name}}Holder new holder with default value, then read from source.
+func New{{.Name}}Holder(prefix string, {{ template "source-param" . }}) (*{{.Name}}Holder, error) {
+	if prefix == "" {
+		panic("config prefix invalid")
+	}
+    h := &{{.Name}}Holder{}
+    cfg := NewDefault{{.Name}}(prefix)
+    if err := cfg.refresh({{ template "source-arg" . }}); err != nil {
+        return nil, err
+    }
+{{- if .Overlay }}
+    if err := cfg.overlay(); err != nil {
+        return nil, err
+    }
+{{- end }}
+{{- if .Secret }}
+    if err := cfg.resolveSecrets(cfg.secretResolver().Resolve); err != nil {
+        return nil, err
+    }
+{{- end }}
+    if err := cfg.validate(); err != nil {
+        return nil, err
+    }
+    h.value.Store(cfg)
+    return h, nil
+}
+{{ template "holder" . }}
+{{- end }}
+{{- end }}
+`
+
+// fileTemplate local file backend, read by viper and watch by fsnotify.
+// used with viperTemplate.
+var fileTemplate = `
+{{- define "extra-imports" }}
+    "path/filepath"
+    "github.com/fsnotify/fsnotify"
+{{- end }}
+
+{{- define "extra-source" }}
+{{- if not .Child }}
+
+// New{{.Name}}FromFile new config with default value, then read from file.
+// root of file is config value, format is detected by extension, yaml, json or toml.
+func New{{.Name}}FromFile(prefix, file string) (*{{.Name}}, error) {
+    vp, err := read{{.Name}}File(prefix, file)
+    if err != nil {
+        return nil, err
+    }
+    return New{{.Name}}(prefix, vp)
+}
+
+// WatchFile refresh config after file changed, changes in debounce duration are merged.
+// onError is called with read or refresh error, current value keep unchanged. call stop to stop watching.
+func (cfg *{{.Name}}) WatchFile(file string, debounce time.Duration, onError func(error)) (stop func(), err error) {
+    return watch{{.Name}}File(cfg.prefix, file, debounce, cfg.RefreshValue, onError)
+}
+{{- if .Atomic }}
+
+// New{{.Name}}HolderFromFile new holder with default value, then read from file.
+// root of file is config value, format is detected by extension, yaml, json or toml.
+func New{{.Name}}HolderFromFile(prefix, file string) (*{{.Name}}Holder, error) {
+    vp, err := read{{.Name}}File(prefix, file)
+    if err != nil {
+        return nil, err
+    }
+    return New{{.Name}}Holder(prefix, vp)
+}
+
+// WatchFile refresh config after file changed, see {{.Name}}.WatchFile
+func (h *{{.Name}}Holder) WatchFile(file string, debounce time.Duration, onError func(error)) (stop func(), err error) {
+    return watch{{.Name}}File(h.Load().prefix, file, debounce, h.RefreshValue, onError)
+}
+{{- end }}
+
+// read{{.Name}}File read file as value of prefix, keys not in file use default value.
+func read{{.Name}}File(prefix, file string) (*viper.Viper, error) {
+    src := viper.New()
+    src.SetConfigFile(file)
+    if err := src.ReadInConfig(); err != nil {
+        return nil, err
+    }
+    vp := viper.New()
+    NewDefault{{.Name}}(prefix).SetDefaultValue(vp)
+    // merge as config of prefix, keys in file are reported by InConfig
+    settings := src.AllSettings()
+    keys := strings.Split(prefix, ".")
+    for k := len(keys) - 1; k >= 0; k-- {
+        settings = map[string]interface{}{keys[k]: settings}
+    }
+    if err := vp.MergeConfigMap(settings); err != nil {
+        return nil, err
+    }
+    return vp, nil
+}
+
+// watch{{.Name}}File watch directory of file, editors and kubernetes configmap replace file by rename.
+// only events of file and changes of symlink target reload file.
+func watch{{.Name}}File(prefix, file string, debounce time.Duration, refresh func(vp *viper.Viper) error, onError func(error)) (stop func(), err error) {
+    file, err = filepath.Abs(file)
+    if err != nil {
+        return nil, err
+    }
+    file = filepath.Clean(file)
+    // kubernetes configmap file is symlink, target is replaced by swap symlink of data directory
+    realFile, _ := filepath.EvalSymlinks(file)
+    watcher, err := fsnotify.NewWatcher()
+    if err != nil {
+        return nil, err
+    }
+    if err = watcher.Add(filepath.Dir(file)); err != nil {
+        watcher.Close()
+        return nil, err
+    }
+    report := func(err error) {
+        if err != nil && onError != nil {
+            onError(err)
+        }
+    }
+    var mutex sync.Mutex
+    reload := func() {
+        mutex.Lock()
+        defer mutex.Unlock()
+        vp, err := read{{.Name}}File(prefix, file)
+        if err != nil {
+            report(err)
+            return
+        }
+        report(refresh(vp))
+    }
+    done := make(chan struct{})
+    go func() {
+        var timer *time.Timer
+        defer func() {
+            if timer != nil {
+                timer.Stop()
+            }
+        }()
+        for {
+            select {
+            case <-done:
+                return
+            case event, ok := <-watcher.Events:
+                if !ok {
+                    return
+                }
+                // file replaced by rename is reported as Create or Rename of it
+                changed := filepath.Clean(event.Name) == file &&
+                    event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0
+                if current, _ := filepath.EvalSymlinks(file); current != "" && current != realFile {
+                    realFile = current
+                    changed = true
+                }
+                if !changed {
+                    continue
+                }
+                if timer == nil {
+                    timer = time.AfterFunc(debounce, reload)
+                } else {
+                    timer.Reset(debounce)
+                }
+            case err, ok := <-watcher.Errors:
+                if !ok {
+                    return
+                }
+                report(err)
+            }
+        }
+    }()
+    var once sync.Once
+    return func() {
+        once.Do(func() {
+            close(done)
+            watcher.Close()
+        })
+    }, nil
+}
+{{- end }}
+{{- end }}
 `
@@ -0,0 +1,191 @@
+package imake
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/aggronmagi/gogen/gen"
+	"github.com/aggronmagi/gogen/goparse"
+	"github.com/aggronmagi/gogen/internal/util"
+)
+
+// generateDecorator generate middleware decorators of interfaces to <output>_decorator.go
+func generateDecorator(pkg *goparse.Package, data map[string]*StructInfo, imports []string, fromPkg, dstPkg string) {
+	file := outputFile("decorator")
+	if len(dstPkg) > 0 {
+		imports = append(imports, fmt.Sprintf("%s \"%s\"", fromPkg, pkg.Package().PkgPath))
+	}
+	g := newGenerator(imports, "context", "log/slog", "time")
+	for _, key := range sortMapKey(data) {
+		info := data[key]
+		if config.MergeUnexportIFace && !token.IsExported(info.Typ) && info.compositeOnly {
+			continue
+		}
+		iface := GetIfaceName(info.Typ)
+		if !token.IsExported(iface) {
+			continue
+		}
+		writeDecorator(g, iface, info.TypeParams, info.TypeArgs, mockMethods(pkg, data, info, dstPkg))
+	}
+	util.FatalIfErr(g.Write(file), "write decorator file")
+}
+
+// writeDecorator write middleware chain, decorator and hook adapters of interface.
+// typeParams and typeArgs are not empty if interface is generic.
+func writeDecorator(g *gen.Generator, iface, typeParams, typeArgs string, methods []*StructMethod) {
+	g.Printf(`
+// %[1]sCall call info of %[1]s method passed to hooks
+type %[1]sCall struct {
+	// Method method name
+	Method string
+	// Ctx context argument if method has one, Before hook can replace it. eg: start trace span
+	Ctx context.Context
+	// Args arguments, variadic arguments as slice
+	Args []interface{}
+	// Results results, set before After hook
+	Results []interface{}
+	// Err last error result, set before After hook
+	Err error
+	// Duration duration of method call, set before After hook
+	Duration time.Duration
+}
+
+// %[1]sHook hooks around %[1]s method call, nil hook is skipped.
+type %[1]sHook struct {
+	Before func(call *%[1]sCall)
+	After  func(call *%[1]sCall)
+}
+
+// %[1]sMiddleware chain of hooks, Before hooks are called in order, After hooks in reverse order.
+type %[1]sMiddleware []%[1]sHook
+
+func (m %[1]sMiddleware) before(call *%[1]sCall) {
+	for _, h := range m {
+		if h.Before != nil {
+			h.Before(call)
+		}
+	}
+}
+
+func (m %[1]sMiddleware) after(call *%[1]sCall) {
+	for i := len(m) - 1; i >= 0; i-- {
+		if m[i].After != nil {
+			m[i].After(call)
+		}
+	}
+}
+
+// New%[1]sSlogHook log method call after it returned, failed call is logged at error level.
+// arguments and results are logged, do not use it for sensitive data.
+func New%[1]sSlogHook(logger *slog.Logger, level slog.Level) %[1]sHook {
+	return %[1]sHook{
+		After: func(call *%[1]sCall) {
+			ctx := call.Ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			attrs := []slog.Attr{
+				slog.String("method", call.Method),
+				slog.Duration("duration", call.Duration),
+				slog.Any("args", call.Args),
+			}
+			if call.Err != nil {
+				logger.LogAttrs(ctx, slog.LevelError, "%[1]s."+call.Method, append(attrs, slog.Any("error", call.Err))...)
+				return
+			}
+			logger.LogAttrs(ctx, level, "%[1]s."+call.Method, append(attrs, slog.Any("results", call.Results))...)
+		},
+	}
+}
+
+// %[1]sMetrics generic metrics recorder. eg: adapter of prometheus histogram
+type %[1]sMetrics interface {
+	ObserveCall(method string, duration time.Duration, err error)
+}
+
+// New%[1]sMetricsHook observe duration and error of method call
+func New%[1]sMetricsHook(metrics %[1]sMetrics) %[1]sHook {
+	return %[1]sHook{
+		After: func(call *%[1]sCall) {
+			metrics.ObserveCall(call.Method, call.Duration, call.Err)
+		},
+	}
+}
+`, iface)
+	g.Printf(`
+// %[1]sDecorator implement %[1]s, call hooks of middleware around method of next
+type %[1]sDecorator%[2]s struct {
+	next       %[1]s%[3]s
+	middleware %[1]sMiddleware
+}
+`, iface, typeParams, typeArgs)
+	if typeParams == "" {
+		g.Printf(`
+// Ensure, that %[1]sDecorator does implement %[1]s.
+var _ %[1]s = &%[1]sDecorator{}
+
+// Wrap decorate next by middleware
+func (m %[1]sMiddleware) Wrap(next %[1]s) *%[1]sDecorator {
+	return &%[1]sDecorator{next: next, middleware: m}
+}
+`, iface)
+	} else {
+		// method can not have type parameters
+		g.Printf(`
+// Wrap%[1]s decorate next by middleware
+func Wrap%[1]s%[2]s(m %[1]sMiddleware, next %[1]s%[3]s) *%[1]sDecorator%[3]s {
+	return &%[1]sDecorator%[3]s{next: next, middleware: m}
+}
+`, iface, typeParams, typeArgs)
+	}
+	for _, method := range methods {
+		params := mockParams(method.Params, "arg")
+		results := mockParams(method.Results, "ret")
+		names := make([]string, 0, len(params))
+		values := make([]string, 0, len(params))
+		for _, p := range params {
+			values = append(values, p.Name)
+			if p.Variadic {
+				names = append(names, p.Name+"...")
+				continue
+			}
+			names = append(names, p.Name)
+		}
+		rnames := make([]string, 0, len(results))
+		for _, r := range results {
+			rnames = append(rnames, r.Name)
+		}
+		g.Printf("\n// %s call hooks of middleware around next.%s\n", method.Name, method.Name)
+		g.Printf("func (d *%sDecorator%s) %s(%s) %s {\n", iface, typeArgs, method.Name,
+			mockArgs(params, func(p *mockParam) string { return p.Type }), mockRets(method))
+		g.Printf("\tcall := &%sCall{Method: %q, Args: []interface{}{%s}}\n", iface, method.Name, strings.Join(values, ", "))
+		// context argument can be replaced by hooks
+		hasCtx := len(params) > 0 && params[0].Type == "context.Context"
+		if hasCtx {
+			g.Printf("\tcall.Ctx = %s\n", params[0].Name)
+		}
+		g.Printf("\td.middleware.before(call)\n")
+		if hasCtx {
+			g.Printf("\t%s = call.Ctx\n", params[0].Name)
+		}
+		g.Printf("\tstart := time.Now()\n")
+		if len(results) == 0 {
+			g.Printf("\td.next.%s(%s)\n", method.Name, strings.Join(names, ", "))
+		} else {
+			g.Printf("\t%s := d.next.%s(%s)\n", strings.Join(rnames, ", "), method.Name, strings.Join(names, ", "))
+		}
+		g.Printf("\tcall.Duration = time.Since(start)\n")
+		if len(results) > 0 {
+			g.Printf("\tcall.Results = []interface{}{%s}\n", strings.Join(rnames, ", "))
+			if last := results[len(results)-1]; last.Type == "error" {
+				g.Printf("\tcall.Err = %s\n", last.Name)
+			}
+		}
+		g.Printf("\td.middleware.after(call)\n")
+		if len(results) > 0 {
+			g.Printf("\treturn %s\n", strings.Join(rnames, ", "))
+		}
+		g.Printf("}\n")
+	}
+}
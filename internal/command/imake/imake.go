@@ -1,14 +1,11 @@
 package imake
 
 import (
-	"bytes"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"log"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
@@ -38,6 +35,9 @@ var config = struct {
 	OtherStructs         []string          // 其他包引入的结构体接口
 	IFaceMap             map[string]string // 接口名称映射
 	Mock                 bool              // 为结构体生成mock
+	MockStyle            string            // mock代码风格 gomock,moq,testify
+	Stub                 string            // mock桩代码方式 monkey,wrap
+	Decorator            bool              // 为接口生成中间件装饰器
 	MergeUnexportIFace   bool              // 将未导出的结构体接口合并
 	SortByPos            bool              // 函数以定义的顺序排序
 	BuildTags            []string
@@ -49,10 +49,12 @@ var config = struct {
 	IgnoreUnexportMethod: true,
 	IFaceMap:             make(map[string]string),
 	SortByPos:            true,
+	MockStyle:            "gomock",
+	Stub:                 "monkey",
 }
 
 // Version generate tool version
-var Version string = "0.0.7"
+var Version string = "0.0.13"
 
 // Flags generate tool flags
 func Flags(set *pflag.FlagSet) {
@@ -69,7 +71,10 @@ func Flags(set *pflag.FlagSet) {
 	set.StringVarP(&config.IfaceSufix, "suffix", "s", config.IfaceSufix, "add interface name suffix")
 	set.StringVarP(&config.IfacePrefix, "prefix", "p", config.IfacePrefix, "add interface name suffix")
 	set.StringSliceVar(&config.BuildTags, "tags", config.BuildTags, "comma-separated list of build tags to apply")
-	set.BoolVar(&config.Mock, "mock", config.Mock, "generate struct mock functions")
+	set.BoolVar(&config.Mock, "mock", config.Mock, "generate gomock(go.uber.org/mock) mocks and monkey stub of structs")
+	set.StringVar(&config.MockStyle, "mock-style", config.MockStyle, "mock style, gomock(go.uber.org/mock with stub), moq(function field fakes) or testify(github.com/stretchr/testify/mock)")
+	set.StringVar(&config.Stub, "stub", config.Stub, "stub style of mock, monkey(patch struct methods by bou.ke/monkey) or wrap(wrapper delegate to real implement, override by mock)")
+	set.BoolVar(&config.Decorator, "decorator", config.Decorator, "generate middleware decorator of interfaces, with slog and metrics hooks")
 	set.BoolVar(&config.MergeUnexportIFace, "merge", config.MergeUnexportIFace, "merge unexport struct method to interface")
 	set.BoolVar(&config.SortByPos, "sort-by-pos", config.SortByPos, "sort method by code pos")
 	set.StringSliceVar(&config.OtherStructs, "merge-other", config.OtherStructs, "merge other package struct or struct name")
@@ -93,15 +98,22 @@ func RunCommand(cmd *cobra.Command, args []string) {
 		config.match = regexp.MustCompile(config.StructMatch)
 	}
 
+	for _, name := range []string{"mock-style", "stub"} {
+		if !config.Mock && cmd.Flags().Changed(name) {
+			log.Fatalf("option %s requires --mock", name)
+		}
+	}
+	if config.Mock && config.MockStyle != "gomock" && config.MockStyle != "moq" && config.MockStyle != "testify" {
+		log.Fatalf("mock style %s not support, use gomock, moq or testify", config.MockStyle)
+	}
+	if config.Mock && config.Stub != "monkey" && config.Stub != "wrap" {
+		log.Fatalf("stub style %s not support, use monkey or wrap", config.Stub)
+	}
+
 	if config.ToPkg == "." {
 		config.ToPkg = goparse.EnvGoPackage
 	}
 
-	// // check mockgen
-	// if config.Mock {
-	// 	exec.Command("command","-v", "mockgen").Run()
-	// }
-
 	// util.Dump(config)
 
 	////////////////////////////////////////////////////////////////////////////////
@@ -141,6 +153,7 @@ func RunCommand(cmd *cobra.Command, args []string) {
 	g.Printf("\n")
 	g.Println("import (")
 	trimPackageNames := make([]string, 0, 8)
+	imports := make([]string, 0, len(pkg.Package().Imports))
 	for _, v := range pkg.Package().Imports {
 		trimPkg := false
 		for _, tpkg := range config.TrimPackage {
@@ -153,7 +166,10 @@ func RunCommand(cmd *cobra.Command, args []string) {
 		if trimPkg {
 			continue
 		}
-		g.Printf("%s \"%s\"\n", v.Name, v.PkgPath)
+		imports = append(imports, fmt.Sprintf("%s \"%s\"", v.Name, v.PkgPath))
+	}
+	for _, v := range imports {
+		g.Println(v)
 	}
 	config.TrimPackage = trimPackageNames
 	if len(dstPkg) > 0 {
@@ -173,7 +189,7 @@ func RunCommand(cmd *cobra.Command, args []string) {
 		// util.Dump(info, "info")
 		info.Methods = sortMethod(info.Methods)
 		g.PrintDoc(info.Doc)
-		g.Printf("type %s interface{\n", GetIfaceName(info.Typ))
+		g.Printf("type %s%s interface{\n", GetIfaceName(info.Typ), info.TypeParams)
 		for _, v := range info.Composites {
 			if !v.IsStruct {
 				// interface composite
@@ -182,12 +198,12 @@ func RunCommand(cmd *cobra.Command, args []string) {
 			}
 			stInfo, stOk := data[v.Typ]
 			if config.MergeUnexportIFace && !token.IsExported(v.Typ) && stOk {
-				for _, method := range stInfo.Methods {
+				for _, method := range stInfo.instantiate(v) {
 					g.PrintDoc(method.Doc)
 					g.Println(method)
 				}
 			} else {
-				g.Printf("%s\n", GetIfaceName(v.Typ))
+				g.Printf("%s%s\n", GetIfaceName(v.Typ), v.TypeArgs)
 			}
 		}
 		// util.Dump(info.Composites)
@@ -208,128 +224,21 @@ func RunCommand(cmd *cobra.Command, args []string) {
 	err = g.Write(config.Output)
 	util.FatalIfErr(err, "format and write result")
 	////////////////////////////////////////////////////////////////////////////////
+	// decorator
+	if config.Decorator {
+		generateDecorator(pkg, data, imports, fromPkg, dstPkg)
+	}
+	////////////////////////////////////////////////////////////////////////////////
 	// mock
 	if !config.Mock {
 		return
 	}
-	// check mockgen version
-	var mockver string
-	func() {
-		out := bytes.NewBuffer(nil)
-		mockcmd := exec.Command("mockgen", "-version")
-		mockcmd.Stdout = out
-		err = mockcmd.Run()
-		util.FatalIfErr(err, "check mockgen version")
-		//fmt.Println("mockgen version:", string(out.Bytes()))
-		mockver = string(out.Bytes())
-	}()
-	// generate mockgen file
-	mockfile := filepath.Join(filepath.Dir(config.Output), "mockgen.go")
-	func() {
-		mockcmd := exec.Command("mockgen", "-source", config.Output,
-			"-destination", mockfile,
-			"-package", config.ToPkg,
-		)
-		mockcmd.Stdout = os.Stdout
-		mockcmd.Stderr = os.Stderr
-		util.FatalIfErr(mockcmd.Run(), "generate mock file")
-	}()
-	// parse mockgen file
-	mockPkg, err := goparse.ParsePackage([]string{mockfile})
-	util.FatalIfErr(err, "parse mockgen generate file")
-	mockData := ParsePackages(mockPkg,
-		// Parse Struct
-		func(name string) bool {
-			// not generate mock recorder
-			if strings.HasSuffix(name, "Recorder") {
-				return false
-			}
-			// only generate mock struct
-			if !strings.HasPrefix(name, "Mock") {
-				return false
-			}
-
-			// ignore unexport type
-			name = strings.TrimPrefix(name, "Mock")
-			if !token.IsExported(name) {
-				return false
-			}
-			return true
-		},
-		// Parse Method
-		func(name string) bool {
-			// mock add functions.
-			if name == "EXPECT" {
-				return false
-			}
-			return true
-		},
-		"",
-	)
-	// util.Dump(mockData, "mockData")
-	g = &gen.Generator{}
-	g.FormatSource = gen.OptionGoimportsFormtat
-	// Print the header and package clause.
-	g.Printf("// Code generated by \"gogen imake\"; DO NOT EDIT.\n")
-	g.Printf("// Exec: \"gogen %s\"\n// Version: %s \n", strings.Join(os.Args[1:], " "), Version)
-	g.Printf("// mockgen: %s\n", mockver)
-	g.Printf("\n")
-	g.Printf("package %s", config.ToPkg)
-	g.Printf("\n")
-	if len(dstPkg) > 0 {
-		g.Println(`
-import (
-	"reflect"
-
-	"bou.ke/monkey"
-	"github.com/golang/mock/gomock"`)
-		g.Printf("%s \"%s\"\n", fromPkg, pkg.Package().PkgPath)
-		g.Println(")")
-	}
-
-	mockKeys := sortMapKey(mockData)
-	for _, key := range mockKeys {
-		info := mockData[key]
-		info.Methods = sortMethod(info.Methods)
-		//
-		var stName string = strings.TrimPrefix(info.Typ, "Mock")
-		stName = strings.TrimSuffix(stName, config.IfaceSufix)
-		stFull := stName
-		if len(dstPkg) > 0 {
-			stFull = dstPkg + "." + stName
-		}
+	generateMock(pkg, data, imports, fromPkg, dstPkg)
+}
 
-		g.Printf(`// Stub%[1]sMock stub struct %[1]s
-func Stub%[1]sMock(ctl *gomock.Controller) (mock *Mock%[1]s%[2]s,st *%[3]s) {
-    mock = NewMock%[1]s%[2]s(ctl)
-    st = &%[3]s{}
-`,
-			stName, config.IfaceSufix, stFull)
-		for _, method := range info.Methods {
-			// no result
-			if len(method.Results) < 1 {
-				g.Printf(`// stub %[2]s
-	monkey.PatchInstanceMethod(reflect.TypeOf(st), "%[2]s",
-		func(_ *%[1]s,%[3]s) (%[4]s) {
-			mock.%[2]s(%[5]s)
-            return
-		},
-	)
-`, stFull, method.Name, method.Args(), method.Rets(), method.Args2())
-				continue
-			}
-			g.Printf(`// stub %[2]s
-	monkey.PatchInstanceMethod(reflect.TypeOf(st), "%[2]s",
-		func(_ *%[1]s,%[3]s) (%[4]s) {
-			return mock.%[2]s(%[5]s)
-		},
-	)
-`, stFull, method.Name, method.Args(), method.Rets(), method.Args2())
-		}
-		g.Printf("\nreturn\n}\n")
-	}
-	util.FatalIfErr(g.Write(filepath.Join(filepath.Dir(mockfile), "stub.go")),
-		"write stub file")
+// outputFile file name beside output, <output>_<suffix>.go
+func outputFile(suffix string) string {
+	return strings.TrimSuffix(config.Output, ".go") + "_" + suffix + ".go"
 }
 
 func trimPkg(typ string) string {
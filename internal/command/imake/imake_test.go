@@ -0,0 +1,42 @@
+package imake
+
+import (
+	"testing"
+
+	"github.com/aggronmagi/gogen/internal/gentest"
+	"github.com/spf13/cobra"
+)
+
+var defaultConfig = config
+
+// modules used by generated code
+const (
+	modGomock  = "go.uber.org/mock v0.6.0"
+	modMonkey  = "bou.ke/monkey v1.0.2"
+	modTestify = "github.com/stretchr/testify v1.12.1"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := []gentest.Case{
+		{Dir: "gomock", Requires: []string{modGomock, modMonkey}},
+		{Dir: "wrap", Requires: []string{modGomock}},
+		{Dir: "moq"},
+		{Dir: "testify", Requires: []string{modTestify}},
+		{Dir: "decorator"},
+		{Dir: "generic", Requires: []string{modGomock}},
+	}
+	for _, c := range cases {
+		t.Run(c.Dir, func(t *testing.T) {
+			c.Run(t, func(args []string) {
+				config = defaultConfig
+				config.IFaceMap = make(map[string]string)
+				cmd := &cobra.Command{Use: "imake"}
+				Flags(cmd.Flags())
+				if err := cmd.ParseFlags(args); err != nil {
+					t.Fatal(err)
+				}
+				RunCommand(cmd, cmd.Flags().Args())
+			})
+		})
+	}
+}
@@ -0,0 +1,531 @@
+package imake
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aggronmagi/gogen/gen"
+	"github.com/aggronmagi/gogen/goparse"
+	"github.com/aggronmagi/gogen/internal/util"
+)
+
+// mockParam flatten param or result of method
+type mockParam struct {
+	Name     string
+	Type     string
+	Variadic bool
+}
+
+// mockParams flatten fields, param named by position. eg: arg0, arg1
+func mockParams(in []*StructField, prefix string) (out []*mockParam) {
+	for _, field := range in {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			p := &mockParam{
+				Name: fmt.Sprintf("%s%d", prefix, len(out)),
+				Type: field.Type,
+			}
+			if strings.HasPrefix(p.Type, "...") {
+				p.Variadic = true
+				p.Type = strings.TrimPrefix(p.Type, "...")
+			}
+			out = append(out, p)
+		}
+	}
+	return
+}
+
+// mockArgs param declare list. eg: "arg0 int, arg1 ...string"
+func mockArgs(params []*mockParam, typ func(p *mockParam) string) string {
+	list := make([]string, 0, len(params))
+	for _, p := range params {
+		if p.Variadic {
+			list = append(list, p.Name+" ..."+typ(p))
+			continue
+		}
+		list = append(list, p.Name+" "+typ(p))
+	}
+	return strings.Join(list, ", ")
+}
+
+// mockRets unnamed result list of method. eg: "(int, error)"
+func mockRets(method *StructMethod) string {
+	results := mockParams(method.Results, "ret")
+	rets := make([]string, 0, len(results))
+	for _, r := range results {
+		rets = append(rets, r.Type)
+	}
+	if len(rets) > 1 {
+		return "(" + strings.Join(rets, ", ") + ")"
+	}
+	return strings.Join(rets, ", ")
+}
+
+// mockCallArgs arguments of call. variadic param collected into varargs
+func mockCallArgs(g *gen.Generator, params []*mockParam, recorder bool) string {
+	if len(params) == 0 {
+		return ""
+	}
+	last := params[len(params)-1]
+	if !last.Variadic {
+		names := make([]string, 0, len(params))
+		for _, p := range params {
+			names = append(names, p.Name)
+		}
+		return ", " + strings.Join(names, ", ")
+	}
+	names := make([]string, 0, len(params))
+	for _, p := range params[:len(params)-1] {
+		names = append(names, p.Name)
+	}
+	if recorder {
+		g.Printf("\tvarargs := append([]interface{}{%s}, %s...)\n", strings.Join(names, ", "), last.Name)
+	} else {
+		g.Printf("\tvarargs := []interface{}{%s}\n", strings.Join(names, ", "))
+		g.Printf("\tfor _, a := range %s {\n\t\tvarargs = append(varargs, a)\n\t}\n", last.Name)
+	}
+	return ", varargs..."
+}
+
+// mockMethods method set of struct interface, include methods of composites. sorted by name.
+func mockMethods(pkg *goparse.Package, data map[string]*StructInfo, info *StructInfo, dstPkg string) []*StructMethod {
+	methods := make(map[string]*StructMethod, len(info.Methods))
+	// names type parameter name of struct => type argument
+	var collect func(info *StructInfo, names map[string]string)
+	collect = func(info *StructInfo, names map[string]string) {
+		for _, v := range info.Composites {
+			if st, ok := data[v.Typ]; ok && v.IsStruct {
+				collect(st, st.typeArgNames(v, names))
+				continue
+			}
+			args := make([]string, 0, len(v.args))
+			for _, arg := range v.args {
+				args = append(args, renameTypeParams(arg, names))
+			}
+			for _, method := range lookupMethods(pkg, v.Typ, dstPkg, args) {
+				methods[method.Name] = method
+			}
+		}
+		for _, method := range sortMethod(info.renameMethods(names)) {
+			methods[method.Name] = method
+		}
+	}
+	collect(info, nil)
+	list := make([]*StructMethod, 0, len(methods))
+	for _, method := range methods {
+		list = append(list, method)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// lookupMethods find methods of composite type by type info, type parameters of generic type
+// are replaced by args. exit if not found.
+func lookupMethods(pkg *goparse.Package, name, dstPkg string, args []string) (methods []*StructMethod) {
+	src := pkg.Package().Types
+	scope := src.Scope()
+	if len(dstPkg) > 0 {
+		name = strings.TrimPrefix(name, dstPkg+".")
+	}
+	if i := strings.Index(name, "."); i > 0 {
+		scope = nil
+		for _, v := range src.Imports() {
+			if v.Name() == name[:i] {
+				scope = v.Scope()
+				break
+			}
+		}
+		name = name[i+1:]
+	}
+	var obj types.Object
+	if scope != nil {
+		obj = scope.Lookup(name)
+	}
+	if obj == nil {
+		log.Fatalf("mock: composite type %s not found", name)
+	}
+	qualifier := func(p *types.Package) string {
+		if p == src {
+			return dstPkg
+		}
+		return p.Name()
+	}
+	names := make(map[string]string)
+	if named, ok := obj.Type().(*types.Named); ok {
+		for i := 0; i < named.TypeParams().Len() && i < len(args); i++ {
+			names[named.TypeParams().At(i).Obj().Name()] = args[i]
+		}
+	}
+	if iface, ok := obj.Type().Underlying().(*types.Interface); ok {
+		for i := 0; i < iface.NumMethods(); i++ {
+			methods = append(methods, renameMethod(typesMethod(iface.Method(i), qualifier), names))
+		}
+		return
+	}
+	mset := types.NewMethodSet(types.NewPointer(obj.Type()))
+	for i := 0; i < mset.Len(); i++ {
+		fn := mset.At(i).Obj().(*types.Func)
+		if !IsGenerateMethod(fn.Name()) || ignoreMethod(fn.Name()) {
+			continue
+		}
+		// receiver may use different type parameter names with declaration
+		recvNames := make(map[string]string)
+		recv := fn.Type().(*types.Signature).RecvTypeParams()
+		for i := 0; i < recv.Len() && i < len(args); i++ {
+			recvNames[recv.At(i).Obj().Name()] = args[i]
+		}
+		methods = append(methods, renameMethod(typesMethod(fn, qualifier), recvNames))
+	}
+	return
+}
+
+// typesMethod convert method of type info
+func typesMethod(fn *types.Func, qualifier types.Qualifier) *StructMethod {
+	sig := fn.Type().(*types.Signature)
+	method := &StructMethod{Name: fn.Name()}
+	for i := 0; i < sig.Params().Len(); i++ {
+		param := sig.Params().At(i)
+		field := &StructField{Type: trimPkg(types.TypeString(param.Type(), qualifier))}
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			field.Type = "..." + trimPkg(types.TypeString(param.Type().(*types.Slice).Elem(), qualifier))
+		}
+		if param.Name() != "" {
+			field.Names = []string{param.Name()}
+		}
+		method.Params = append(method.Params, field)
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		method.Results = append(method.Results, &StructField{
+			Type: trimPkg(types.TypeString(sig.Results().At(i).Type(), qualifier)),
+		})
+	}
+	return method
+}
+
+// generateMock generate mocks of interfaces to <output>_mock.go by mock style.
+// gomock style also write stubs to <output>_stub.go, monkey stubs of structs or wrappers of interfaces.
+func generateMock(pkg *goparse.Package, data map[string]*StructInfo, imports []string, fromPkg, dstPkg string) {
+	mockfile := outputFile("mock")
+	stubfile := outputFile("stub")
+	removeStaleMock()
+	if len(dstPkg) > 0 {
+		imports = append(imports, fmt.Sprintf("%s \"%s\"", fromPkg, pkg.Package().PkgPath))
+	}
+	var mock, stub *gen.Generator
+	switch config.MockStyle {
+	case "moq":
+		mock = newGenerator(imports, "sync")
+	case "testify":
+		mock = newGenerator(imports, "github.com/stretchr/testify/mock")
+	default:
+		mock = newGenerator(imports, "reflect", "go.uber.org/mock/gomock")
+	}
+	if config.Stub == "wrap" {
+		stub = newGenerator(imports, "sync", "go.uber.org/mock/gomock")
+	} else {
+		stub = newGenerator(imports, "reflect", "bou.ke/monkey", "go.uber.org/mock/gomock")
+	}
+
+	for _, key := range sortMapKey(data) {
+		info := data[key]
+		if config.MergeUnexportIFace && !token.IsExported(info.Typ) && info.compositeOnly {
+			continue
+		}
+		iface := GetIfaceName(info.Typ)
+		if !token.IsExported(iface) {
+			continue
+		}
+		methods := mockMethods(pkg, data, info, dstPkg)
+		switch config.MockStyle {
+		case "moq":
+			writeMoq(mock, iface+"Mock", iface, info.TypeParams, info.TypeArgs, methods)
+			continue
+		case "testify":
+			writeTestify(mock, "Mock"+iface, iface, info.TypeParams, info.TypeArgs, methods)
+			continue
+		}
+		writeMock(mock, "Mock"+iface, iface, info.TypeParams, info.TypeArgs, methods)
+		if config.Stub == "wrap" {
+			writeWrapStub(stub, info.Typ+"Stub", iface, "Mock"+iface, info.TypeParams, info.TypeArgs, methods)
+			continue
+		}
+		// monkey can not patch methods of generic struct
+		if info.TypeParams != "" {
+			log.Printf("stub of generic struct %s is not supported by monkey, use --stub wrap", info.Typ)
+			continue
+		}
+		// only struct can be stubbed
+		obj := pkg.Package().Types.Scope().Lookup(info.Typ)
+		if obj == nil {
+			continue
+		}
+		if _, ok := obj.Type().Underlying().(*types.Struct); !ok {
+			continue
+		}
+		stFull := info.Typ
+		if len(dstPkg) > 0 {
+			stFull = dstPkg + "." + info.Typ
+		}
+		writeStub(stub, info.Typ, stFull, "Mock"+iface, methods)
+	}
+	util.FatalIfErr(mock.Write(mockfile), "write mock file")
+	if config.MockStyle != "gomock" {
+		return
+	}
+	util.FatalIfErr(stub.Write(stubfile), "write stub file")
+}
+
+// staleMockFiles files written by previous version beside output, and header of them.
+// mocks were generated to mockgen.go by mockgen, stubs to stub.go.
+var staleMockFiles = map[string]string{
+	"mockgen.go": "// Code generated by MockGen. DO NOT EDIT.",
+	"stub.go":    "// Code generated by \"gogen imake\"; DO NOT EDIT.",
+}
+
+// removeStaleMock remove mock and stub files generated by previous version,
+// they declare the same mocks and stubs. files not generated are kept.
+func removeStaleMock() {
+	for name, header := range staleMockFiles {
+		file := filepath.Join(filepath.Dir(config.Output), name)
+		if file == filepath.Clean(config.Output) {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil || !strings.HasPrefix(string(data), header) {
+			continue
+		}
+		util.FatalIfErr(os.Remove(file), "remove stale file "+file)
+		log.Println("remove", file, "generated by previous version")
+	}
+}
+
+// newGenerator generator with header, imports of source package and extra import paths.
+// unused imports are removed by goimports.
+func newGenerator(imports []string, paths ...string) *gen.Generator {
+	g := &gen.Generator{}
+	g.FormatSource = gen.OptionGoimportsFormtat
+	g.Printf("// Code generated by \"gogen imake\"; DO NOT EDIT.\n")
+	g.Printf("// Exec: \"gogen %s\"\n// Version: %s \n", strings.Join(os.Args[1:], " "), Version)
+	g.Printf("\n")
+	g.Printf("package %s\n", config.ToPkg)
+	g.Println("import (")
+	for _, v := range imports {
+		g.Println(v)
+	}
+	for _, path := range paths {
+		// imports of source are written with package name
+		imported := false
+		for _, v := range imports {
+			if strings.HasSuffix(v, strconv.Quote(path)) {
+				imported = true
+				break
+			}
+		}
+		if !imported {
+			g.Println(strconv.Quote(path))
+		}
+	}
+	g.Println(")")
+	return g
+}
+
+// writeMock write gomock mock of interface. typeParams and typeArgs are not empty if interface is generic.
+func writeMock(g *gen.Generator, name, iface, typeParams, typeArgs string, methods []*StructMethod) {
+	g.Printf(`
+// %[1]s is a mock of %[2]s interface.
+type %[1]s%[3]s struct {
+	ctrl     *gomock.Controller
+	recorder *%[1]sMockRecorder%[4]s
+}
+
+// %[1]sMockRecorder is the mock recorder for %[1]s.
+type %[1]sMockRecorder%[3]s struct {
+	mock *%[1]s%[4]s
+}
+
+// New%[1]s creates a new mock instance.
+func New%[1]s%[3]s(ctrl *gomock.Controller) *%[1]s%[4]s {
+	mock := &%[1]s%[4]s{ctrl: ctrl}
+	mock.recorder = &%[1]sMockRecorder%[4]s{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *%[1]s%[4]s) EXPECT() *%[1]sMockRecorder%[4]s {
+	return m.recorder
+}
+`, name, iface, typeParams, typeArgs)
+	for _, method := range methods {
+		params := mockParams(method.Params, "arg")
+		results := mockParams(method.Results, "ret")
+		// mock method
+		g.Printf("\n// %s mocks base method.\n", method.Name)
+		g.Printf("func (m *%s%s) %s(%s) %s {\n", name, typeArgs, method.Name,
+			mockArgs(params, func(p *mockParam) string { return p.Type }), mockRets(method))
+		g.Printf("\tm.ctrl.T.Helper()\n")
+		args := mockCallArgs(g, params, false)
+		if len(results) == 0 {
+			g.Printf("\tm.ctrl.Call(m, %q%s)\n}\n", method.Name, args)
+		} else {
+			g.Printf("\tret := m.ctrl.Call(m, %q%s)\n", method.Name, args)
+			names := make([]string, 0, len(results))
+			for k, r := range results {
+				g.Printf("\t%s, _ := ret[%d].(%s)\n", r.Name, k, r.Type)
+				names = append(names, r.Name)
+			}
+			g.Printf("\treturn %s\n}\n", strings.Join(names, ", "))
+		}
+		// recorder method
+		g.Printf("\n// %s indicates an expected call of %s.\n", method.Name, method.Name)
+		g.Printf("func (mr *%sMockRecorder%s) %s(%s) *gomock.Call {\n", name, typeArgs, method.Name,
+			mockArgs(params, func(p *mockParam) string { return "interface{}" }))
+		g.Printf("\tmr.mock.ctrl.T.Helper()\n")
+		args = mockCallArgs(g, params, true)
+		g.Printf("\treturn mr.mock.ctrl.RecordCallWithMethodType(mr.mock, %q, reflect.TypeOf((*%s%s)(nil).%s)%s)\n}\n",
+			method.Name, name, typeArgs, method.Name, args)
+	}
+}
+
+// writeStub write stub func patch struct methods to mock by monkey
+func writeStub(g *gen.Generator, stName, stFull, mockName string, methods []*StructMethod) {
+	// variables of stub must not shadow types used by methods
+	mock := stubIdent("mock", stFull, methods)
+	st := stubIdent("st", stFull, methods)
+	g.Printf(`
+// Stub%[1]sMock stub struct %[1]s
+func Stub%[1]sMock(ctl *gomock.Controller) (%[4]s *%[2]s, %[5]s *%[3]s) {
+	%[4]s = New%[2]s(ctl)
+	%[5]s = &%[3]s{}
+`, stName, mockName, stFull, mock, st)
+	for _, method := range methods {
+		params := mockParams(method.Params, "arg")
+		names := make([]string, 0, len(params))
+		for _, p := range params {
+			if p.Variadic {
+				names = append(names, p.Name+"...")
+				continue
+			}
+			names = append(names, p.Name)
+		}
+		ret := "return "
+		if len(method.Results) == 0 {
+			ret = ""
+		}
+		g.Printf(`	// stub %[2]s
+	monkey.PatchInstanceMethod(reflect.TypeOf(%[8]s), %[2]q,
+		func(_ *%[1]s, %[3]s) %[4]s {
+			%[5]s%[7]s.%[2]s(%[6]s)
+		},
+	)
+`, stFull, method.Name, mockArgs(params, func(p *mockParam) string { return p.Type }),
+			mockRets(method), ret, strings.Join(names, ", "), mock, st)
+	}
+	g.Printf("\treturn\n}\n")
+}
+
+// stubIdent identifier based on name, not used by struct and types of methods.
+func stubIdent(name, stFull string, methods []*StructMethod) string {
+	used := make(map[string]bool)
+	mark := func(typ string) {
+		for _, id := range identRegexp.FindAllString(typ, -1) {
+			used[id] = true
+		}
+	}
+	mark(stFull)
+	for _, method := range methods {
+		for _, field := range append(append([]*StructField{}, method.Params...), method.Results...) {
+			mark(field.Type)
+		}
+	}
+	ident := name
+	for i := 0; used[ident]; i++ {
+		ident = fmt.Sprintf("%s%d", name, i)
+	}
+	return ident
+}
+
+var identRegexp = regexp.MustCompile(`[\p{L}_][\p{L}\p{N}_]*`)
+
+// writeWrapStub write wrapper of interface, delegate to real implement by default,
+// mocked method call mock instead. no code patch, safe for race detector.
+func writeWrapStub(g *gen.Generator, name, iface, mockName, typeParams, typeArgs string, methods []*StructMethod) {
+	g.Printf(`
+// %[1]s wrap %[2]s, call real implement by default, method mocked by Mock<Method> call mock instead.
+type %[1]s%[4]s struct {
+	%[2]s%[5]s
+	mock   *%[3]s%[5]s
+	mutex  sync.RWMutex
+	mocked map[string]bool
+}
+
+// New%[1]s new stub delegate to real implement
+func New%[1]s%[4]s(ctl *gomock.Controller, real %[2]s%[5]s) *%[1]s%[5]s {
+	return &%[1]s%[5]s{
+		%[2]s: real,
+		mock:   New%[3]s%[5]s(ctl),
+		mocked: make(map[string]bool),
+	}
+}
+
+// isMocked method is mocked
+func (s *%[1]s%[5]s) isMocked(method string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.mocked[method]
+}
+
+// setMocked method call mock since now
+func (s *%[1]s%[5]s) setMocked(method string) *%[3]sMockRecorder%[5]s {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.mocked[method] = true
+	return s.mock.EXPECT()
+}
+`, name, iface, mockName, typeParams, typeArgs)
+	for _, method := range methods {
+		params := mockParams(method.Params, "arg")
+		names := make([]string, 0, len(params))
+		for _, p := range params {
+			if p.Variadic {
+				names = append(names, p.Name+"...")
+				continue
+			}
+			names = append(names, p.Name)
+		}
+		args := strings.Join(names, ", ")
+		mockCall := fmt.Sprintf("return s.mock.%s(%s)", method.Name, args)
+		realCall := fmt.Sprintf("return s.%s.%s(%s)", iface, method.Name, args)
+		if len(method.Results) == 0 {
+			mockCall = fmt.Sprintf("s.mock.%s(%s)\n\t\treturn", method.Name, args)
+			realCall = fmt.Sprintf("s.%s.%s(%s)", iface, method.Name, args)
+		}
+		g.Printf(`
+// Mock%[2]s call mock for %[2]s, return mock recorder to set expected call.
+func (s *%[1]s%[8]s) Mock%[2]s() *%[3]sMockRecorder%[8]s {
+	return s.setMocked(%[2]q)
+}
+
+// %[2]s call mock if mocked, otherwise call real implement.
+func (s *%[1]s%[8]s) %[2]s(%[4]s) (%[5]s) {
+	if s.isMocked(%[2]q) {
+		%[6]s
+	}
+	%[7]s
+}
+`, name, method.Name, mockName, mockArgs(params, func(p *mockParam) string { return p.Type }),
+			method.Rets(), mockCall, realCall, typeArgs)
+	}
+}
@@ -0,0 +1,130 @@
+package imake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aggronmagi/gogen/gen"
+)
+
+// moqParams flatten params, use param name of source if possible, otherwise named by position.
+func moqParams(in []*StructField) (out []*mockParam) {
+	// names used by generated code
+	used := map[string]bool{"mock": true, "callInfo": true, "calls": true, "args": true, "run": true}
+	out = mockParams(in, "arg")
+	// positional names are kept by params not use name of source
+	for _, p := range out {
+		used[p.Name] = true
+		used[moqFieldName(p.Name)] = true
+	}
+	k := 0
+	for _, field := range in {
+		if len(field.Names) == 0 {
+			k++
+			continue
+		}
+		for _, name := range field.Names {
+			if name != "_" && !used[name] && !used[moqFieldName(name)] {
+				out[k].Name = name
+				used[name] = true
+				used[moqFieldName(name)] = true
+			}
+			k++
+		}
+	}
+	return
+}
+
+// moqFieldName field name of call info
+func moqFieldName(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// moqCallInfo struct type of call info
+func moqCallInfo(params []*mockParam) string {
+	if len(params) == 0 {
+		return "struct{}"
+	}
+	fields := make([]string, 0, len(params))
+	for _, p := range params {
+		typ := p.Type
+		if p.Variadic {
+			typ = "[]" + typ
+		}
+		fields = append(fields, fmt.Sprintf("\t%s %s\n", moqFieldName(p.Name), typ))
+	}
+	return "struct {\n" + strings.Join(fields, "") + "}"
+}
+
+// writeMoq write moq style fake of interface, method is implemented by <Method>Func field,
+// calls are recorded and returned by <Method>Calls.
+func writeMoq(g *gen.Generator, name, iface, typeParams, typeArgs string, methods []*StructMethod) {
+	// generic interface can not be checked without type arguments
+	if typeParams == "" {
+		g.Printf(`
+// Ensure, that %[1]s does implement %[2]s.
+var _ %[2]s = &%[1]s{}
+`, name, iface)
+	}
+	g.Printf(`
+// %[1]s is a fake implementation of %[2]s, method call <Method>Func field.
+type %[1]s%[3]s struct {
+`, name, iface, typeParams)
+	for _, method := range methods {
+		params := moqParams(method.Params)
+		g.Printf("\t// %sFunc mocks the %s method.\n", method.Name, method.Name)
+		g.Printf("\t%sFunc func(%s) %s\n\n", method.Name,
+			mockArgs(params, func(p *mockParam) string { return p.Type }), mockRets(method))
+	}
+	g.Printf("\t// calls tracks calls to the methods.\n\tcalls struct {\n")
+	for _, method := range methods {
+		g.Printf("\t\t// %s holds details about calls to the %s method.\n", method.Name, method.Name)
+		g.Printf("\t\t%s []%s\n", method.Name, moqCallInfo(moqParams(method.Params)))
+	}
+	g.Printf("\t}\n")
+	for _, method := range methods {
+		g.Printf("\tlock%s sync.RWMutex\n", method.Name)
+	}
+	g.Printf("}\n")
+
+	for _, method := range methods {
+		params := moqParams(method.Params)
+		callInfo := moqCallInfo(params)
+		names := make([]string, 0, len(params))
+		values := make([]string, 0, len(params))
+		for _, p := range params {
+			values = append(values, fmt.Sprintf("%s: %s,\n", moqFieldName(p.Name), p.Name))
+			if p.Variadic {
+				names = append(names, p.Name+"...")
+				continue
+			}
+			names = append(names, p.Name)
+		}
+		ret := "return "
+		if len(method.Results) == 0 {
+			ret = ""
+		}
+		g.Printf(`
+// %[2]s calls %[2]sFunc.
+func (mock *%[1]s%[10]s) %[2]s(%[4]s) %[5]s {
+	if mock.%[2]sFunc == nil {
+		panic("%[1]s.%[2]sFunc: method is nil but %[3]s.%[2]s was just called")
+	}
+	callInfo := %[6]s{
+		%[7]s}
+	mock.lock%[2]s.Lock()
+	mock.calls.%[2]s = append(mock.calls.%[2]s, callInfo)
+	mock.lock%[2]s.Unlock()
+	%[8]smock.%[2]sFunc(%[9]s)
+}
+
+// %[2]sCalls gets all the calls that were made to %[2]s.
+func (mock *%[1]s%[10]s) %[2]sCalls() []%[6]s {
+	mock.lock%[2]s.RLock()
+	defer mock.lock%[2]s.RUnlock()
+	return append([]%[6]s(nil), mock.calls.%[2]s...)
+}
+`, name, method.Name, iface, mockArgs(params, func(p *mockParam) string { return p.Type }),
+			mockRets(method), callInfo, strings.Join(values, ""), ret, strings.Join(names, ", "), typeArgs)
+	}
+}
@@ -3,8 +3,10 @@ package imake
 import (
 	"fmt"
 	"go/ast"
+	"go/parser"
 	"go/token"
 	"log"
+	"regexp"
 	"strings"
 
 	"github.com/aggronmagi/gogen/goparse"
@@ -74,6 +76,10 @@ func (m *StructMethod) Rets() string {
 type CompositeStructInfo struct {
 	Typ      string
 	IsStruct bool
+	// TypeArgs type arguments of generic composite. eg: "[T]"
+	TypeArgs string
+	typeArgs []ast.Expr
+	args     []string
 }
 
 type StructInfo struct {
@@ -83,6 +89,106 @@ type StructInfo struct {
 	Comment       string
 	Methods       []*StructMethod
 	compositeOnly bool
+	// TypeParams type parameters with constraints of generic struct. eg: "[K comparable, V any]"
+	TypeParams string
+	// TypeArgs type parameter names of generic struct. eg: "[K, V]"
+	TypeArgs       string
+	typeParamNames []string
+}
+
+// typeArgNames type parameter name => type argument of composite, type arguments
+// are renamed by names of embedding struct.
+func (info *StructInfo) typeArgNames(composite *CompositeStructInfo, outer map[string]string) map[string]string {
+	names := make(map[string]string)
+	for k, v := range composite.args {
+		if k < len(info.typeParamNames) {
+			names[info.typeParamNames[k]] = renameTypeParams(v, outer)
+		}
+	}
+	return names
+}
+
+// instantiate methods of generic struct with type arguments of composite
+func (info *StructInfo) instantiate(composite *CompositeStructInfo) []*StructMethod {
+	return info.renameMethods(info.typeArgNames(composite, nil))
+}
+
+// renameMethods methods with type parameter names replaced
+func (info *StructInfo) renameMethods(names map[string]string) []*StructMethod {
+	methods := make([]*StructMethod, 0, len(info.Methods))
+	for _, method := range info.Methods {
+		methods = append(methods, renameMethod(method, names))
+	}
+	return methods
+}
+
+// genericExpr split generic type expression to type and type arguments. eg: Stack[T]
+func genericExpr(expr ast.Expr) (ast.Expr, []ast.Expr) {
+	switch v := expr.(type) {
+	case *ast.IndexExpr:
+		return v.X, []ast.Expr{v.Index}
+	case *ast.IndexListExpr:
+		return v.X, v.Indices
+	}
+	return expr, nil
+}
+
+// renameTypeParams replace type parameter names in type. eg: receiver use different names with declaration
+// identifiers are renamed through ast, qualified identifiers (pkg.T) and field names are kept.
+func renameTypeParams(typ string, names map[string]string) string {
+	if len(names) == 0 {
+		return typ
+	}
+	// variadic param type
+	variadic := strings.HasPrefix(typ, "...")
+	fset := token.NewFileSet()
+	expr, err := parser.ParseExprFrom(fset, "", strings.TrimPrefix(typ, "..."), 0)
+	util.FatalIfErr(err, "parse type "+typ)
+	keep := make(map[*ast.Ident]bool)
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.SelectorExpr:
+			keep[v.Sel] = true
+			if x, ok := v.X.(*ast.Ident); ok {
+				keep[x] = true
+			}
+		case *ast.Field:
+			for _, name := range v.Names {
+				keep[name] = true
+			}
+		}
+		return true
+	})
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && !keep[id] {
+			if name, ok := names[id.Name]; ok {
+				id.Name = name
+			}
+		}
+		return true
+	})
+	typ = goparse.Format(fset, expr)
+	if variadic {
+		typ = "..." + typ
+	}
+	return typ
+}
+
+// renameMethod copy of method with type parameter names replaced
+func renameMethod(method *StructMethod, names map[string]string) *StructMethod {
+	if len(names) == 0 {
+		return method
+	}
+	cp := *method
+	rename := func(in []*StructField) (out []*StructField) {
+		for _, v := range in {
+			out = append(out, &StructField{Names: v.Names, Type: renameTypeParams(v.Type, names)})
+		}
+		return
+	}
+	cp.Params = rename(method.Params)
+	cp.Results = rename(method.Results)
+	return &cp
 }
 
 // parse dst package. collecte structs infos
@@ -95,6 +201,7 @@ func ParsePackages(pkg *goparse.Package,
 	data = make(map[string]*StructInfo, 16)
 
 	stCheck := make(map[string]struct{}, 128)
+	typeSpecs := make(map[string]*ast.TypeSpec, 128)
 
 	// range type declare
 	pkg.GenDecl(func(decl *ast.GenDecl, cm ast.CommentMap) bool {
@@ -105,6 +212,7 @@ func ParsePackages(pkg *goparse.Package,
 		for _, spec := range decl.Specs {
 			tspec := spec.(*ast.TypeSpec)
 			stCheck[tspec.Name.String()] = struct{}{}
+			typeSpecs[tspec.Name.String()] = tspec
 			// check is generate struct by config
 			if !IsGenerateStruct(tspec.Name.String()) {
 				continue
@@ -131,7 +239,17 @@ func ParsePackages(pkg *goparse.Package,
 					continue
 				}
 				var ident *ast.Ident
-				switch fv := field.Type.(type) {
+				// generic composite. eg: Base[T], *Base[T]
+				var typeArgs []ast.Expr
+				ftype := field.Type
+				if star, ok := ftype.(*ast.StarExpr); ok {
+					if x, args := genericExpr(star.X); len(args) > 0 {
+						ftype, typeArgs = &ast.StarExpr{X: x}, args
+					}
+				} else {
+					ftype, typeArgs = genericExpr(ftype)
+				}
+				switch fv := ftype.(type) {
 				case *ast.Ident:
 					// struct composite
 					ident = fv
@@ -195,6 +313,7 @@ func ParsePackages(pkg *goparse.Package,
 					st.Composites = append(st.Composites, &CompositeStructInfo{
 						Typ:      ident.Name,
 						IsStruct: true,
+						typeArgs: typeArgs,
 					})
 					continue
 				}
@@ -228,6 +347,7 @@ func ParsePackages(pkg *goparse.Package,
 				st.Composites = append(st.Composites, &CompositeStructInfo{
 					Typ:      ct,
 					IsStruct: !isInterface,
+					typeArgs: typeArgs,
 				})
 			}
 
@@ -252,19 +372,68 @@ func ParsePackages(pkg *goparse.Package,
 	if len(dstPackage) == 0 {
 		changeType = nil
 	}
+	// type parameters of generic structs and type arguments of composites
+	formatExpr := func(expr ast.Expr) string {
+		typ := goparse.Format(pkg.Fset(), expr)
+		if changeType != nil {
+			typ = formatType(typ, changeType)
+		}
+		return trimPkg(typ)
+	}
+	for name, info := range data {
+		for _, v := range info.Composites {
+			if len(v.typeArgs) == 0 {
+				continue
+			}
+			args := make([]string, 0, len(v.typeArgs))
+			for _, arg := range v.typeArgs {
+				args = append(args, formatExpr(arg))
+			}
+			v.TypeArgs = "[" + strings.Join(args, ", ") + "]"
+			v.args = args
+		}
+		tspec, ok := typeSpecs[name]
+		if !ok || tspec.TypeParams == nil {
+			continue
+		}
+		params := make([]string, 0, len(tspec.TypeParams.List))
+		for _, field := range tspec.TypeParams.List {
+			names := make([]string, 0, len(field.Names))
+			for _, v := range field.Names {
+				names = append(names, v.Name)
+			}
+			info.typeParamNames = append(info.typeParamNames, names...)
+			params = append(params, strings.Join(names, ", ")+" "+formatExpr(field.Type))
+		}
+		info.TypeParams = "[" + strings.Join(params, ", ") + "]"
+		info.TypeArgs = "[" + strings.Join(info.typeParamNames, ", ") + "]"
+	}
 	// range func declare
 	pkg.FuncDecl(func(decl *ast.FuncDecl, cm ast.CommentMap) bool {
 		// ignore not method
 		if decl.Recv == nil {
 			return true
 		}
-		name := goparse.Format(pkg.Fset(), decl.Recv.List[0].Type)
-		name = strings.TrimPrefix(name, "*")
+		recv := decl.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		recv, recvParams := genericExpr(recv)
+		name := goparse.Format(pkg.Fset(), recv)
 		// find type info
 		info, ok := data[name]
 		if !ok {
 			return true
 		}
+		// receiver may use different type parameter names with declaration
+		rename := make(map[string]string)
+		for k, v := range recvParams {
+			ident, ok := v.(*ast.Ident)
+			if !ok || k >= len(info.typeParamNames) || ident.Name == "_" || ident.Name == info.typeParamNames[k] {
+				continue
+			}
+			rename[ident.Name] = info.typeParamNames[k]
+		}
 		// check export method by config
 		if !IsGenerateMethod(decl.Name.String()) {
 			return true
@@ -292,7 +461,7 @@ func ParsePackages(pkg *goparse.Package,
 		method.fileLine = pos.Line
 		method.Params = ToFileds(pkg.Fset(), decl.Type.Params, changeType)
 		method.Results = ToFileds(pkg.Fset(), decl.Type.Results, changeType)
-		info.Methods = append(info.Methods, method)
+		info.Methods = append(info.Methods, renameMethod(method, rename))
 		return true
 	})
 	return
@@ -352,5 +521,35 @@ func formatType(typ string, changeType func(string) string) string {
 	if strings.HasPrefix(typ, "chan ") {
 		return "chan " + formatType(strings.TrimPrefix(typ, "chan "), changeType)
 	}
+	// generic type. eg: Stack[T], Pair[K, []V]
+	if i := strings.Index(typ, "["); i > 0 && strings.HasSuffix(typ, "]") && typeNameRegexp.MatchString(typ[:i]) {
+		args := splitTypeArgs(typ[i+1 : len(typ)-1])
+		for k, v := range args {
+			args[k] = formatType(v, changeType)
+		}
+		return changeType(typ[:i]) + "[" + strings.Join(args, ", ") + "]"
+	}
 	return changeType(typ)
 }
+
+// typeNameRegexp type name, may be qualified by package
+var typeNameRegexp = regexp.MustCompile(`^[\w.]+$`)
+
+// splitTypeArgs split type arguments by top level comma
+func splitTypeArgs(in string) (out []string) {
+	depth, start := 0, 0
+	for k, v := range in {
+		switch v {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				out = append(out, strings.TrimSpace(in[start:k]))
+				start = k + 1
+			}
+		}
+	}
+	return append(out, strings.TrimSpace(in[start:]))
+}
@@ -0,0 +1,29 @@
+package store
+
+import "time"
+
+//go:generate gogen imake . -t Stack,Pair -o iface.go --mock --stub wrap
+
+type Stack[T any] struct{ items []T }
+
+func (s *Stack[T]) Push(v ...T) {}
+
+func (s *Stack[T]) Pop() (v T, ok bool) { return }
+
+// Delay type parameter has same name with qualified type time.Duration
+type Delay[Duration any] struct{}
+
+func (d *Delay[Duration]) After(v time.Duration) Duration {
+	var r Duration
+	return r
+}
+
+type Pair[K comparable, V any] struct {
+	*Stack[V]
+	*Delay[K]
+}
+
+func (p *Pair[K, V]) Get(key K) (V, bool) {
+	var v V
+	return v, false
+}
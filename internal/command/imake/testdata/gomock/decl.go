@@ -0,0 +1,33 @@
+package store
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate gogen imake . -t Store -o iface.go --mock
+
+type Item struct{}
+
+type base struct{}
+
+func (b *base) Close() error { return nil }
+
+type Store struct {
+	*base
+}
+
+// Get get item by key
+func (s *Store) Get(ctx context.Context, key string) (*Item, error) { return nil, nil }
+
+// Put put items, named results must not shadow generated variables.
+func (s *Store) Put(ctx context.Context, items ...*Item) (mock int, err error) { return 0, nil }
+
+// Dump write items
+func (s *Store) Dump(w io.Writer) {}
+
+// mock type name must not be shadowed by generated variables.
+type mock int
+
+// SetMode set mode
+func (s *Store) SetMode(m mock) {}
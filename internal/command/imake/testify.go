@@ -0,0 +1,170 @@
+package imake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aggronmagi/gogen/gen"
+)
+
+// testifyFuncType func type of method, without names. eg: "func(int, ...string) error"
+func testifyFuncType(params []*mockParam, rets string) string {
+	types := make([]string, 0, len(params))
+	for _, p := range params {
+		if p.Variadic {
+			types = append(types, "..."+p.Type)
+			continue
+		}
+		types = append(types, p.Type)
+	}
+	return strings.TrimSpace(fmt.Sprintf("func(%s) %s", strings.Join(types, ", "), rets))
+}
+
+// writeTestify write testify(github.com/stretchr/testify/mock) mock of interface,
+// with mockery style typed EXPECT helpers.
+func writeTestify(g *gen.Generator, name, iface, typeParams, typeArgs string, methods []*StructMethod) {
+	g.Printf(`
+// %[1]s is a testify mock of %[2]s.
+type %[1]s%[3]s struct {
+	mock.Mock
+}
+
+// New%[1]s creates a new mock instance, expectations are asserted when test finished.
+func New%[1]s%[3]s(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *%[1]s%[4]s {
+	m := &%[1]s%[4]s{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// %[1]s_Expecter typed helpers to define mock.On call
+type %[1]s_Expecter%[3]s struct {
+	mock *mock.Mock
+}
+
+// EXPECT returns typed helpers to define mock.On call.
+func (_m *%[1]s%[4]s) EXPECT() *%[1]s_Expecter%[4]s {
+	return &%[1]s_Expecter%[4]s{mock: &_m.Mock}
+}
+`, name, iface, typeParams, typeArgs)
+	for _, method := range methods {
+		writeTestifyMethod(g, name, typeParams, typeArgs, method)
+	}
+}
+
+func writeTestifyMethod(g *gen.Generator, name, typeParams, typeArgs string, method *StructMethod) {
+	params := moqParams(method.Params)
+	results := mockParams(method.Results, "_r")
+	rets := mockRets(method)
+	names := make([]string, 0, len(params))
+	for _, p := range params {
+		if p.Variadic {
+			names = append(names, p.Name+"...")
+			continue
+		}
+		names = append(names, p.Name)
+	}
+	args := strings.Join(names, ", ")
+	call := fmt.Sprintf("%s_%s_Call", name, method.Name)
+	// call type is generic too, callT is instantiated type
+	callT := call + typeArgs
+
+	// mock method
+	g.Printf("\n// %s provides a mock function of %s\n", method.Name, method.Name)
+	g.Printf("func (_m *%s%s) %s(%s) %s {\n", name, typeArgs, method.Name,
+		mockArgs(params, func(p *mockParam) string { return p.Type }), rets)
+	// variadic arguments are passed one by one
+	called := make([]string, 0, len(params))
+	for _, p := range params {
+		if !p.Variadic {
+			called = append(called, p.Name)
+		}
+	}
+	if len(params) > 0 && params[len(params)-1].Variadic {
+		last := params[len(params)-1]
+		g.Printf("\t_ca := []interface{}{%s}\n", strings.Join(called, ", "))
+		g.Printf("\tfor _, _a := range %s {\n\t\t_ca = append(_ca, _a)\n\t}\n", last.Name)
+		called = []string{"_ca..."}
+	}
+	if len(results) == 0 {
+		g.Printf("\t_m.Called(%s)\n}\n", strings.Join(called, ", "))
+	} else {
+		g.Printf("\t_ret := _m.Called(%s)\n", strings.Join(called, ", "))
+		// RunAndReturn
+		g.Printf("\tif _rf, ok := _ret.Get(0).(%s); ok {\n\t\treturn _rf(%s)\n\t}\n",
+			testifyFuncType(params, rets), args)
+		rnames := make([]string, 0, len(results))
+		for k, r := range results {
+			g.Printf("\tvar %s %s\n", r.Name, r.Type)
+			g.Printf("\tif _rf, ok := _ret.Get(%d).(%s); ok {\n\t\t%s = _rf(%s)\n\t} else if _v, ok := _ret.Get(%d).(%s); ok {\n\t\t%s = _v\n\t}\n",
+				k, testifyFuncType(params, r.Type), r.Name, args, k, r.Type, r.Name)
+			rnames = append(rnames, r.Name)
+		}
+		g.Printf("\treturn %s\n}\n", strings.Join(rnames, ", "))
+	}
+
+	// expecter
+	g.Printf(`
+// %[1]s typed call of %[2]s
+type %[1]s%[3]s struct {
+	*mock.Call
+}
+`, call, method.Name, typeParams)
+	g.Printf("\n// %s is a helper method to define mock.On call\n", method.Name)
+	g.Printf("func (_e *%s_Expecter%s) %s(%s) *%s {\n", name, typeArgs, method.Name,
+		mockArgs(params, func(p *mockParam) string { return "interface{}" }), callT)
+	on := make([]string, 0, len(params)+1)
+	on = append(on, fmt.Sprintf("%q", method.Name))
+	for _, p := range params {
+		if !p.Variadic {
+			on = append(on, p.Name)
+		}
+	}
+	if len(params) > 0 && params[len(params)-1].Variadic {
+		last := params[len(params)-1]
+		g.Printf("\treturn &%s{Call: _e.mock.On(%q, append([]interface{}{%s}, %s...)...)}\n}\n",
+			callT, method.Name, strings.Join(on[1:], ", "), last.Name)
+	} else {
+		g.Printf("\treturn &%s{Call: _e.mock.On(%s)}\n}\n", callT, strings.Join(on, ", "))
+	}
+
+	// Run convert arguments to typed value
+	g.Printf("\n// Run set handler called with typed arguments\n")
+	g.Printf("func (_c *%s) Run(run func(%s)) *%s {\n", callT,
+		mockArgs(params, func(p *mockParam) string { return p.Type }), callT)
+	g.Printf("\t_c.Call.Run(func(args mock.Arguments) {\n")
+	for k, p := range params {
+		if p.Variadic {
+			g.Printf("\t\t%s := make([]%s, 0, len(args)-%d)\n", p.Name, p.Type, k)
+			g.Printf("\t\tfor _, _a := range args[%d:] {\n\t\t\t_v, _ := _a.(%s)\n\t\t\t%s = append(%s, _v)\n\t\t}\n",
+				k, p.Type, p.Name, p.Name)
+			continue
+		}
+		g.Printf("\t\t%s, _ := args.Get(%d).(%s)\n", p.Name, k, p.Type)
+	}
+	g.Printf("\t\trun(%s)\n\t})\n\treturn _c\n}\n", args)
+
+	// Return typed results
+	g.Printf("\n// Return set typed return values\n")
+	rparams := make([]string, 0, len(results))
+	rvalues := make([]string, 0, len(results))
+	for k, r := range results {
+		rparams = append(rparams, fmt.Sprintf("_a%d %s", k, r.Type))
+		rvalues = append(rvalues, fmt.Sprintf("_a%d", k))
+	}
+	g.Printf("func (_c *%s) Return(%s) *%s {\n\t_c.Call.Return(%s)\n\treturn _c\n}\n",
+		callT, strings.Join(rparams, ", "), callT, strings.Join(rvalues, ", "))
+
+	// RunAndReturn
+	g.Printf("\n// RunAndReturn set func to compute return values\n")
+	if len(results) == 0 {
+		g.Printf("func (_c *%s) RunAndReturn(run %s) *%s {\n\t_c.Run(run)\n\treturn _c\n}\n",
+			callT, testifyFuncType(params, rets), callT)
+		return
+	}
+	g.Printf("func (_c *%s) RunAndReturn(run %s) *%s {\n\t_c.Call.Return(run)\n\treturn _c\n}\n",
+		callT, testifyFuncType(params, rets), callT)
+}
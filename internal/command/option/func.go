@@ -1,13 +1,22 @@
 package option
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
 	"strings"
 	"text/template"
+	"unicode"
+
+	"github.com/aggronmagi/gogen/goparse"
 )
 
+// UseFuncMap functions can be used in templates
 var UseFuncMap = template.FuncMap{}
 
 func init() {
+	// comment rendering
 	UseFuncMap["Doc"] = funDoc
 	UseFuncMap["TailDoc"] = func(docs []string) string {
 		if len(docs) < 1 {
@@ -15,6 +24,26 @@ func init() {
 		}
 		return funDoc(docs[0])
 	}
+	UseFuncMap["DocDeprecated"] = func(docs, deprecated string) string {
+		out := funDoc(docs)
+		if deprecated == "" {
+			return out
+		}
+		if out != "" {
+			out += "//\n"
+		}
+		return out + "// Deprecated: " + deprecated + "\n"
+	}
+	UseFuncMap["OneLine"] = funOneLine
+	UseFuncMap["LineComment"] = func(docs []string) string {
+		if len(docs) < 1 || strings.TrimSpace(docs[0]) == "" {
+			return ""
+		}
+		return "// " + funOneLine(docs[0])
+	}
+	UseFuncMap["Quote"] = strconv.Quote
+
+	// case conversion
 	UseFuncMap["Title"] = func(in string) (out string) {
 		list := strings.Split(in, "_")
 		for _, v := range list {
@@ -22,6 +51,122 @@ func init() {
 		}
 		return
 	}
+	UseFuncMap["CamelCase"] = funCamelCase
+	UseFuncMap["LowerCamelCase"] = func(in string) string {
+		return funLowerFirst(funCamelCase(in))
+	}
+	UseFuncMap["SnakeCase"] = func(in string) string {
+		return strings.Join(splitWords(in), "_")
+	}
+	UseFuncMap["UpperSnakeCase"] = func(in string) string {
+		return strings.ToUpper(strings.Join(splitWords(in), "_"))
+	}
+	UseFuncMap["KebabCase"] = func(in string) string {
+		return strings.Join(splitWords(in), "-")
+	}
+	UseFuncMap["Lower"] = strings.ToLower
+	UseFuncMap["Upper"] = strings.ToUpper
+	UseFuncMap["LowerFirst"] = funLowerFirst
+	UseFuncMap["UpperFirst"] = funUpperFirst
+
+	// type inspection
+	UseFuncMap["IsMap"] = func(typ string) bool {
+		_, ok := parseType(typ).(*ast.MapType)
+		return ok
+	}
+	UseFuncMap["IsPointer"] = func(typ string) bool {
+		_, ok := parseType(typ).(*ast.StarExpr)
+		return ok
+	}
+	UseFuncMap["IsSlice"] = func(typ string) bool {
+		v, ok := parseType(typ).(*ast.ArrayType)
+		return ok && v.Len == nil
+	}
+	UseFuncMap["IsArray"] = func(typ string) bool {
+		v, ok := parseType(typ).(*ast.ArrayType)
+		return ok && v.Len != nil
+	}
+	UseFuncMap["IsFunc"] = func(typ string) bool {
+		_, ok := parseType(typ).(*ast.FuncType)
+		return ok
+	}
+	UseFuncMap["IsChan"] = func(typ string) bool {
+		_, ok := parseType(typ).(*ast.ChanType)
+		return ok
+	}
+	UseFuncMap["IsInterface"] = func(typ string) bool {
+		_, ok := parseType(typ).(*ast.InterfaceType)
+		return ok
+	}
+	UseFuncMap["ElemType"] = func(typ string) string {
+		switch v := parseType(typ).(type) {
+		case *ast.StarExpr:
+			return formatType(v.X)
+		case *ast.ArrayType:
+			return formatType(v.Elt)
+		case *ast.MapType:
+			return formatType(v.Value)
+		case *ast.ChanType:
+			return formatType(v.Value)
+		}
+		return ""
+	}
+	UseFuncMap["KeyType"] = func(typ string) string {
+		if v, ok := parseType(typ).(*ast.MapType); ok {
+			return formatType(v.Key)
+		}
+		return ""
+	}
+
+	// tag building
+	UseFuncMap["Tag"] = func(key string, values ...string) string {
+		list := make([]string, 0, len(values))
+		for _, v := range values {
+			if v != "" {
+				list = append(list, v)
+			}
+		}
+		return key + ":" + strconv.Quote(strings.Join(list, ","))
+	}
+	UseFuncMap["StructTag"] = func(tags ...string) string {
+		list := make([]string, 0, len(tags))
+		for _, v := range tags {
+			if v != "" {
+				list = append(list, v)
+			}
+		}
+		if len(list) < 1 {
+			return ""
+		}
+		return "`" + strings.Join(list, " ") + "`"
+	}
+
+	// strings. input is the last argument, can be used in pipelines.
+	UseFuncMap["Join"] = func(sep string, list []string) string {
+		return strings.Join(list, sep)
+	}
+	UseFuncMap["HasPrefix"] = func(prefix, in string) bool {
+		return strings.HasPrefix(in, prefix)
+	}
+	UseFuncMap["HasSuffix"] = func(suffix, in string) bool {
+		return strings.HasSuffix(in, suffix)
+	}
+	UseFuncMap["TrimPrefix"] = func(prefix, in string) string {
+		return strings.TrimPrefix(in, prefix)
+	}
+	UseFuncMap["TrimSuffix"] = func(suffix, in string) string {
+		return strings.TrimSuffix(in, suffix)
+	}
+	UseFuncMap["Replace"] = func(old, new, in string) string {
+		return strings.Replace(in, old, new, -1)
+	}
+
+	// document
+	UseFuncMap["MarkdownCell"] = func(in string) string {
+		in = strings.TrimSpace(in)
+		in = strings.Replace(in, "|", "\\|", -1)
+		return strings.Replace(in, "\n", "<br>", -1)
+	}
 }
 
 func funDoc(docs string) string {
@@ -38,3 +183,85 @@ func funDoc(docs string) string {
 	}
 	return buf.String()
 }
+
+// funOneLine join multi-line text as one line
+func funOneLine(in string) string {
+	list := strings.Split(strings.TrimSpace(in), "\n")
+	for k, v := range list {
+		list[k] = strings.TrimSpace(v)
+	}
+	return strings.Join(list, " ")
+}
+
+func funLowerFirst(in string) string {
+	if in == "" {
+		return in
+	}
+	r := []rune(in)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func funUpperFirst(in string) string {
+	if in == "" {
+		return in
+	}
+	r := []rune(in)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func funCamelCase(in string) string {
+	words := splitWords(in)
+	for k, v := range words {
+		words[k] = funUpperFirst(v)
+	}
+	return strings.Join(words, "")
+}
+
+// splitWords split name to lower case words. split by '_', '-', ' ' and case
+// change. eg: "HTTPServer_addr" => ["http", "server", "addr"]
+func splitWords(in string) (words []string) {
+	r := []rune(in)
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			words = append(words, strings.ToLower(string(r[start:end])))
+		}
+	}
+	for i := 0; i < len(r); i++ {
+		switch {
+		case r[i] == '_' || r[i] == '-' || r[i] == ' ' || r[i] == '.':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsUpper(r[i]) &&
+			(unicode.IsLower(r[i-1]) || unicode.IsDigit(r[i-1]) ||
+				(i+1 < len(r) && unicode.IsLower(r[i+1]))):
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(r))
+	return
+}
+
+// parseType parse go type expression. return nil if parse failed.
+func parseType(typ string) ast.Expr {
+	expr, err := parser.ParseExpr(typ)
+	if err != nil {
+		return nil
+	}
+	// (*string) => *string
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		expr = paren.X
+	}
+	return expr
+}
+
+func formatType(expr ast.Expr) string {
+	return goparse.Format(token.NewFileSet(), expr)
+}
@@ -14,22 +14,65 @@ import (
 	"github.com/aggronmagi/gogen/internal/util"
 )
 
-func generate(pkg *goparse.Package, st *optionStruct) {
-
-	var data = struct {
-		*optionStruct
-		ExecArgs    string
-		Version     string
-		PackageName string
-		GenAppend   bool
-	}{
-		optionStruct: st,
-		ExecArgs:     strings.Join(os.Args[1:], " "),
-		Version:      Version,
-		PackageName:  pkg.Package().Name,
-		GenAppend:    config.GenAppend,
+// builtinTemplates built-in generate templates, select by template flag.
+var builtinTemplates = map[string]string{
+	"option":    tplOption,
+	"builder":   tplBuilder,
+	"interface": tplInterface,
+	"config":    tplConfig,
+	"markdown":  tplMarkdown,
+	"html":      tplHTML,
+}
+
+// docTemplates built-in document templates and output file extension.
+// output is not go source.
+var docTemplates = map[string]string{
+	"markdown": ".md",
+	"html":     ".html",
+}
+
+func generate(pkg *goparse.Package, st *OptionStruct) {
+	text, ok := builtinTemplates[config.Template]
+	if !ok {
+		if config.Template == "" {
+			log.Println("invalid template config")
+			return
+		}
+		data, err := os.ReadFile(config.Template)
+		if err != nil {
+			log.Println("load config file failed,", err)
+			return
+		}
+		text = string(data)
+	}
+
+	ext, doc := docTemplates[config.Template]
+	if !doc {
+		ext = ".go"
+	}
+	file := config.Output
+	if file == "" {
+		file = "gen_" + strings.ToLower(st.Name) + ext
+	}
+	if !execute(pkg, st, text, file, doc) {
+		return
 	}
 
+	if !config.GenTest || config.Template != "option" {
+		return
+	}
+	if st.TypeParams != "" {
+		log.Println(st.Name, "is generic, unit test not generate")
+		return
+	}
+	execute(pkg, st, tplOptionTest, strings.TrimSuffix(file, ".go")+"_test.go", false)
+}
+
+// execute execute template and save to file. doc output is not go source,
+// save without format.
+func execute(pkg *goparse.Package, st *OptionStruct, text, file string, doc bool) bool {
+	data := newTemplateData(pkg, st)
+
 	tpl := template.New(config.Template).Funcs(UseFuncMap)
 
 	importCache := make([]struct {
@@ -44,7 +87,7 @@ func generate(pkg *goparse.Package, st *optionStruct) {
 		}
 
 		rename := ""
-		if len(alias) >= 0 {
+		if len(alias) > 0 {
 			rename = alias[0]
 			if rename == filepath.Base(pkg) {
 				rename = ""
@@ -78,30 +121,15 @@ func generate(pkg *goparse.Package, st *optionStruct) {
 		"Import": importFunc,
 	})
 
-	var err error
-	switch config.Template {
-	case "option":
-		_, err = tpl.Parse(tplOption)
-	// case "config":
-	// 	_, err = tpl.Parse(tplConfig)
-	default:
-		if config.Template == "" {
-			log.Println("invalid template config")
-			return
-		}
-		var data []byte
-		data, err = os.ReadFile(config.Template)
-		if err != nil {
-			log.Println("load config file failed,", err)
-			return
-		}
-
-		_, err = tpl.Parse(string(data))
+	_, err := tpl.Parse(tplCommon)
+	if err != nil {
+		log.Println("parse common template failed,", err)
+		return false
 	}
-
+	_, err = tpl.Parse(text)
 	if err != nil {
 		log.Println("parse template failed,", err)
-		return
+		return false
 	}
 
 	for _, v := range pkg.Package().Imports {
@@ -112,11 +140,12 @@ func generate(pkg *goparse.Package, st *optionStruct) {
 	err = tpl.Execute(buf, data)
 	if err != nil {
 		log.Println("execute template failed,", err)
-		return
+		return false
 	}
-	file := config.Output
-	if file == "" {
-		file = "gen_" + strings.ToLower(st.Name) + ".go"
+	if doc {
+		err = os.WriteFile(file, buf.Bytes(), 0644)
+		util.FatalIfErr(err, "save output failed")
+		return true
 	}
 
 	bdata := bytes.Replace(buf.Bytes(), []byte("$Import-Package$"), []byte(fmt.Sprintf("import (\n%s)", customImport())), 1)
@@ -126,4 +155,5 @@ func generate(pkg *goparse.Package, st *optionStruct) {
 	}
 	err = g.Write(file)
 	util.FatalIfErr(err, "save output failed")
+	return true
 }
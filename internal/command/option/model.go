@@ -0,0 +1,159 @@
+package option
+
+import (
+	"os"
+	"strings"
+
+	"github.com/aggronmagi/gogen/goparse"
+)
+
+// Data model passed to option templates. Custom templates (-t <file>) and
+// `gogen option --dump-model` use the same model, field and method names
+// are kept stable.
+
+// FieldType option field value kind
+type FieldType int
+
+const (
+	// FieldTypeFunc field default value is function literal
+	FieldTypeFunc FieldType = iota
+	// FieldTypeVar field default value is variable value
+	FieldTypeVar
+)
+
+// OptionField one option entry of declaration map
+type OptionField struct {
+	// Document comments above the entry
+	Document string
+	// Comment comments of the entry value, the first one is line comment
+	Comment []string
+	// FieldType field default value kind
+	FieldType FieldType
+	// Key key of declaration map
+	Key string
+	// FromFunc declaration function the entry declared in
+	FromFunc string
+	// Namespace key of extends entry, prefix of setting function name of extended field.
+	// eg: "Retry": RetryOptionDeclareWithDefault() generate WithRetryTimeout
+	Namespace string
+	// Name field name
+	Name string
+	// Type field go type
+	Type string
+	// Body default value expression
+	Body string
+	// GetMethod string
+
+	// Export setting function exported or not
+	Export bool
+	// Deprecated deprecated message, set by `gogen:deprecated=<message>`
+	Deprecated string
+	// RenamedFrom old names, set by `gogen:renamed-from=OldName`
+	RenamedFrom []string
+}
+
+// IsDeprecated report whether field set deprecated
+func (field *OptionField) IsDeprecated() bool {
+	return field.Deprecated != ""
+}
+
+// RenamedFuncName generate setting function name with old field name
+func (field *OptionField) RenamedFuncName(old, optName string) string {
+	return field.withFuncName(old, optName)
+}
+
+// GenFuncName generate setting function name
+func (field *OptionField) GenFuncName(optName string) string {
+	return field.withFuncName(field.Name, optName)
+}
+
+// AppendFuncName generate append function name of slice field
+func (field *OptionField) AppendFuncName(optName string) string {
+	suffix := field.Namespace + strings.Title(field.Name)
+	if config.FuncWithOptionName {
+		suffix = strings.Title(optName) + suffix
+	}
+	if !field.Export {
+		return "append" + suffix
+	}
+	return "Append" + suffix
+}
+
+// Description field description, document and line comment joined. deprecated
+// message and old names are appended.
+func (field *OptionField) Description() string {
+	desc := strings.TrimSpace(field.Document)
+	if len(field.Comment) > 0 {
+		desc = strings.TrimSpace(desc + "\n" + strings.TrimSpace(field.Comment[0]))
+	}
+	if field.IsDeprecated() {
+		desc = strings.TrimSpace(desc + "\nDeprecated: " + field.Deprecated)
+	}
+	if len(field.RenamedFrom) > 0 {
+		desc = strings.TrimSpace(desc + "\nRenamed from: " + strings.Join(field.RenamedFrom, ", "))
+	}
+	return desc
+}
+
+// IsSlice report whether field is slice, exclude []byte
+func (field *OptionField) IsSlice() bool {
+	return strings.HasPrefix(field.Type, "[]") &&
+		!strings.Contains(field.Type, "byte")
+}
+
+// SliceType slice element type
+func (field *OptionField) SliceType() string {
+	return strings.Replace(field.Type, "[]", "", 1)
+}
+
+// OptionStruct options declared by one declaration function
+type OptionStruct struct {
+	// Document comments of declaration function
+	Document string
+	Comment  []string
+	// Name generate options type name. eg: ConfigOptions
+	Name string
+	// FromFunc declaration function name
+	FromFunc string
+	// OptionName generate option type name. eg: ConfigOption
+	OptionName string
+	// Extends extended declaration function names
+	Extends []string
+	// Fields option fields, include extended fields
+	Fields []*OptionField
+	// TypeParams generic type parameters declare. eg: [K comparable, V any]
+	TypeParams string
+	// TypeArgs generic type arguments. eg: [K, V]
+	TypeArgs string
+	// HasDeprecated some fields deprecated or renamed
+	HasDeprecated bool
+}
+
+// TemplateData data passed to template
+type TemplateData struct {
+	*OptionStruct
+	// ExecArgs command line arguments
+	ExecArgs string
+	// Version gogen option version
+	Version string
+	// PackageName generate package name
+	PackageName string
+	// GenAppend generate append function for slice field
+	GenAppend bool
+	// GenAtomic generate atomic holder
+	GenAtomic bool
+	// GenTest generate unit test
+	GenTest bool
+}
+
+func newTemplateData(pkg *goparse.Package, st *OptionStruct) *TemplateData {
+	return &TemplateData{
+		OptionStruct: st,
+		ExecArgs:     strings.Join(os.Args[1:], " "),
+		Version:      Version,
+		PackageName:  pkg.Package().Name,
+		GenAppend:    config.GenAppend,
+		GenAtomic:    config.GenAtomic,
+		GenTest:      config.GenTest,
+	}
+}
@@ -1,10 +1,12 @@
 package option
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/aggronmagi/gogen/goparse"
@@ -20,6 +22,10 @@ var config = struct {
 	GenAppend          bool
 	Output             string
 	Template           string
+	ScanAll            bool
+	GenAtomic          bool
+	DumpModel          bool
+	GenTest            bool
 }{
 	AllExport: true,
 	Template:  "option",
@@ -50,30 +56,139 @@ func FlagSet(set *pflag.FlagSet) {
 		"decice output file name.",
 	)
 	// 生成模板
-	set.StringVarP(&config.Template, "template", "t", config.Template, "generate template, default use option")
+	set.StringVarP(&config.Template, "template", "t", config.Template,
+		"generate template, built-in option, builder, interface, config, markdown and html, or custom template file")
+	// 生成并发安全的选项持有类型,支持更新和订阅变更
+	set.BoolVar(&config.GenAtomic, "atomic", config.GenAtomic,
+		"generate holder type store options behind atomic.Pointer(go1.19+), support Update and Subscribe",
+	)
+	// 输出模板数据模型,用于编写自定义模板
+	set.BoolVar(&config.DumpModel, "dump-model", config.DumpModel,
+		"print template data model as JSON instead of generate, used to write custom template",
+	)
+	// 生成单元测试
+	set.BoolVar(&config.GenTest, "gen-test", config.GenTest,
+		"generate gen_<name>_test.go check setting functions, default values and append functions",
+	)
+	// 扫描包内所有的选项声明函数
+	set.BoolVar(&config.ScanAll, "all", config.ScanAll,
+		"scan every *DeclareWithDefault function in the package, each generate it's own options",
+	)
 }
 
 // Version option command version
-var Version string = "0.0.4"
+var Version string = "0.0.12"
 
 func RunCommand(cmd *cobra.Command, args ...string) {
 	// parse file from env, which was seted by go generate tool.
-	pkg, optSt := parseGoGenerate()
-	// util.Dump(optSt)
-	optSt.fixStruct()
-	generate(pkg, optSt)
+	if config.GenAtomic && config.Template != "option" {
+		log.Println("atomic holder only generate with option template, ignore")
+	}
+	if config.GenTest && config.Template != "option" {
+		log.Println("unit test only generate with option template, ignore")
+	}
+	pkg, list := parseGoGenerate()
+	if len(list) > 1 && (config.Output != "" || config.OptionsName != "") {
+		log.Fatal("output and options-name not support with multiple option declaration")
+	}
+	funcs := make(map[string]string, 32)
+	for _, optSt := range list {
+		// util.Dump(optSt)
+		optSt.fixStruct()
+		// setting function name maybe duplicate between options
+		for _, f := range optSt.Fields {
+			names := []string{f.GenFuncName(optSt.OptionName)}
+			for _, old := range f.RenamedFrom {
+				names = append(names, f.RenamedFuncName(old, optSt.OptionName))
+			}
+			for _, name := range names {
+				if from, ok := funcs[name]; ok {
+					log.Fatalf("%s function %s duplicate with %s, try use -f flag", optSt.FromFunc, name, from)
+				}
+				funcs[name] = optSt.FromFunc
+			}
+		}
+	}
+	if config.DumpModel {
+		models := make([]*TemplateData, 0, len(list))
+		for _, optSt := range list {
+			models = append(models, newTemplateData(pkg, optSt))
+		}
+		data, err := json.MarshalIndent(models, "", "  ")
+		util.FatalIfErr(err, "marshal template data model failed")
+		fmt.Println(string(data))
+		return
+	}
+	for _, optSt := range list {
+		generate(pkg, optSt)
+	}
+	return
+}
+
+// declareFunc option declaration function
+type declareFunc struct {
+	decl *ast.FuncDecl
+	cm   ast.CommentMap
+}
+
+// isDeclareFunc report whether name is option declaration function name.
+func isDeclareFunc(name string) bool {
+	return strings.HasSuffix(name, "DeclareWithDefault")
+}
+
+// collectDeclareFuncs collect all option declaration functions in package.
+func collectDeclareFuncs(pkg *goparse.Package) (list []string, decls map[string]*declareFunc) {
+	decls = make(map[string]*declareFunc)
+	pkg.FuncDecl(func(decl *ast.FuncDecl, cm ast.CommentMap) bool {
+		if decl.Recv != nil || !isDeclareFunc(decl.Name.Name) {
+			return true
+		}
+		list = append(list, decl.Name.Name)
+		decls[decl.Name.Name] = &declareFunc{
+			decl: decl,
+			cm:   cm,
+		}
+		return true
+	})
 	return
 }
 
 // parseGoGenerate parse and ready generate struct.
-func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
+func parseGoGenerate() (pkg *goparse.Package, list []*OptionStruct) {
 
 	// parse file from env, which was seted by go generate tool.
 	pkg, err := goparse.ParseGeneratePackage()
 	util.FatalIfErr(err, "parse go generate file failed")
+
+	names, decls := collectDeclareFuncs(pkg)
+	// scan all declaration functions in package
+	if config.ScanAll {
+		for _, name := range names {
+			list = append(list, parseDeclareFunc(pkg, decls, decls[name].decl, decls[name].cm, nil))
+		}
+		if len(list) < 1 {
+			log.Fatal("not found any option declaration function")
+		}
+		return
+	}
+
 	node, cm, err := pkg.GetGenerateNode()
 	util.FatalIfErr(err, "find generate ast node failed")
 
+	// Only receive func declare.
+	fdecl, ok := node.(*ast.FuncDecl)
+	if !ok {
+		util.Dump(node)
+		log.Fatal("find ast node is not func type")
+	}
+	list = append(list, parseDeclareFunc(pkg, decls, fdecl, cm, nil))
+	return
+}
+
+// parseDeclareFunc parse option declaration function. stack record the
+// declaration functions being extended, used to check circular extends.
+func parseDeclareFunc(pkg *goparse.Package, decls map[string]*declareFunc,
+	fdecl *ast.FuncDecl, cm ast.CommentMap, stack []string) (optSt *OptionStruct) {
 	// document and comment helper func
 	foreachComment := func(node ast.Node, fc func(g *ast.CommentGroup)) {
 		c, ok := cm[node]
@@ -86,12 +201,14 @@ func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
 		return
 	}
 
-	// Only receive func declare.
-	fdecl, ok := node.(*ast.FuncDecl)
-	if !ok {
-		util.Dump(node)
-		log.Fatal("find ast node is not func type")
+	for _, v := range stack {
+		if v == fdecl.Name.Name {
+			log.Fatal("circular extends option declaration: ",
+				strings.Join(append(stack, v), " -> "))
+		}
 	}
+	stack = append(stack, fdecl.Name.Name)
+
 	// Only allow func has one statement
 	if len(fdecl.Body.List) != 1 {
 		log.Fatal("func not only have one stmt")
@@ -110,12 +227,27 @@ func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
 	if !ok {
 		log.Fatal("Only allow return map literal value")
 	}
-	optSt = &optionStruct{}
+	optSt = &OptionStruct{}
 	// from func name
 	optSt.FromFunc = fdecl.Name.Name
 	optSt.Name = optSt.FromFunc
+	// generic declaration function type parameters
+	if tparams := fdecl.Type.TypeParams; tparams != nil && len(tparams.List) > 0 {
+		params := make([]string, 0, len(tparams.List))
+		args := make([]string, 0, len(tparams.List))
+		for _, v := range tparams.List {
+			names := make([]string, 0, len(v.Names))
+			for _, name := range v.Names {
+				names = append(names, name.Name)
+			}
+			params = append(params, strings.Join(names, ", ")+" "+goparse.Format(pkg.Fset(), v.Type))
+			args = append(args, names...)
+		}
+		optSt.TypeParams = "[" + strings.Join(params, ", ") + "]"
+		optSt.TypeArgs = "[" + strings.Join(args, ", ") + "]"
+	}
 	// node document
-	foreachComment(node, func(g *ast.CommentGroup) {
+	foreachComment(fdecl, func(g *ast.CommentGroup) {
 		if len(optSt.Document) > 0 {
 			optSt.Document += "\n"
 		}
@@ -141,12 +273,38 @@ func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
 			log.Fatal("return value index", k, "key is not string type")
 		}
 
+		// extends other option declaration. reuse it's fields.
+		if name, ok := extendsDeclareFunc(kvexpr.Value); ok {
+			extend, ok := decls[name]
+			if !ok {
+				log.Fatal("extends option declaration ", name, " not found in package")
+			}
+			parent := parseDeclareFunc(pkg, decls, extend.decl, extend.cm, stack)
+			if parent.TypeParams != "" && parent.TypeParams != optSt.TypeParams {
+				log.Fatalf("%s extends %s type parameters %s mismatch %s", optSt.FromFunc,
+					name, parent.TypeParams, optSt.TypeParams)
+			}
+			// key is namespace of setting functions, not collide with setting functions of parent
+			namespace, _ := strconv.Unquote(key.Value)
+			if namespace != "" && !token.IsIdentifier(namespace) {
+				log.Fatalf("%s extends %s key %s is not valid identifier", optSt.FromFunc, name, key.Value)
+			}
+			optSt.Extends = append(optSt.Extends, name)
+			for _, f := range parent.Fields {
+				f.Namespace = strings.Title(namespace) + f.Namespace
+				optSt.Fields = append(optSt.Fields, f)
+			}
+			continue
+		}
+
 		// if !token.IsExported(key.Value) {}
 
 		// key name
-		field := new(optionField)
+		field := new(OptionField)
 		optSt.Fields = append(optSt.Fields, field)
 		field.Name = key.Value
+		field.Key, _ = strconv.Unquote(key.Value)
+		field.FromFunc = optSt.FromFunc
 		// field document
 		foreachComment(elt, func(g *ast.CommentGroup) {
 			if len(field.Document) > 0 {
@@ -234,9 +392,31 @@ func parseGoGenerate() (pkg *goparse.Package, optSt *optionStruct) {
 	return
 }
 
+// extendsDeclareFunc check value is call other option declaration function.
+// eg: "Retry": RetryOptionDeclareWithDefault(),
+func extendsDeclareFunc(expr ast.Expr) (name string, ok bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return "", false
+	}
+	fun := call.Fun
+	// generic declaration function. eg: BaseOptionDeclareWithDefault[K, V]()
+	switch v := fun.(type) {
+	case *ast.IndexExpr:
+		fun = v.X
+	case *ast.IndexListExpr:
+		fun = v.X
+	}
+	ident, ok := fun.(*ast.Ident)
+	if !ok || !isDeclareFunc(ident.Name) {
+		return "", false
+	}
+	return ident.Name, true
+}
+
 // convertCompositeLitBody composite lit body convert
 func convertCompositeLitBody(pkg *goparse.Package, cm ast.CommentMap, val *ast.CompositeLit,
-	field *optionField) (err error) {
+	field *OptionField) (err error) {
 	var data []string
 
 	foreachComment := func(node ast.Node, fc func(g *ast.CommentGroup)) {
@@ -289,27 +469,9 @@ func convertCompositeLitBody(pkg *goparse.Package, cm ast.CommentMap, val *ast.C
 	return nil
 }
 
-type FieldType int
-
-const (
-	FieldTypeFunc FieldType = iota
-	FieldTypeVar
-)
-
-type optionField struct {
-	Document  string
-	Comment   []string
-	FieldType FieldType
-	Name      string
-	Type      string
-	Body      string
-	// GetMethod string
-
-	Export bool
-}
-
-func (field *optionField) fix() {
+func (field *OptionField) fix() {
 	field.Name = strings.Trim(field.Name, "\"")
+	field.parseAnnotations()
 	field.Export = true
 	if !token.IsExported(field.Name) {
 		field.Export = false
@@ -328,48 +490,54 @@ func (field *optionField) fix() {
 	}
 }
 
-func (field *optionField) GenFuncName(optName string) string {
-	suffix := strings.Title(field.Name)
-	if config.FuncWithOptionName {
-		suffix = strings.Title(optName) + suffix
+// parseAnnotations parse gogen annotations from document and comment
+func (field *OptionField) parseAnnotations() {
+	annotations := make(map[string]string)
+	merge := func(text string) string {
+		rest, list := goparse.ParseAnnotations(text)
+		for k, v := range list {
+			annotations[k] = v
+		}
+		return rest
 	}
-	// log.Println("GenFuncName:", config.FuncWithOptionName, fmt.Sprintf("[%s]", optName), "suffix => ", suffix)
-	if !field.Export {
-		return "with" + suffix
+	field.Document = merge(field.Document)
+	for k, v := range field.Comment {
+		field.Comment[k] = merge(v)
+	}
+	for k, v := range annotations {
+		switch k {
+		case "deprecated":
+			field.Deprecated = v
+			if field.Deprecated == "" {
+				field.Deprecated = "do not use."
+			}
+		case "renamed-from":
+			for _, name := range strings.Split(v, ",") {
+				name = strings.TrimSpace(name)
+				if name == "" {
+					continue
+				}
+				field.RenamedFrom = append(field.RenamedFrom, name)
+			}
+		default:
+			log.Printf("field %s annotation %s not support,ignore\n", field.Name, k)
+		}
 	}
-	return "With" + suffix
 }
 
-func (field *optionField) AppendFuncName(optName string) string {
-	suffix := strings.Title(field.Name)
+func (field *OptionField) withFuncName(name, optName string) string {
+	suffix := field.Namespace + strings.Title(name)
 	if config.FuncWithOptionName {
 		suffix = strings.Title(optName) + suffix
 	}
+	// log.Println("GenFuncName:", config.FuncWithOptionName, fmt.Sprintf("[%s]", optName), "suffix => ", suffix)
 	if !field.Export {
-		return "append" + suffix
+		return "with" + suffix
 	}
-	return "Append" + suffix
-}
-
-func (field *optionField) IsSlice() bool {
-	return strings.HasPrefix(field.Type, "[]") &&
-		!strings.Contains(field.Type, "byte")
-}
-
-func (field *optionField) SliceType() string {
-	return strings.Replace(field.Type, "[]", "", 1)
-}
-
-type optionStruct struct {
-	Document   string
-	Comment    []string
-	Name       string
-	FromFunc   string
-	OptionName string
-	Fields     []*optionField
+	return "With" + suffix
 }
 
-func (opt *optionStruct) fixStruct() {
+func (opt *OptionStruct) fixStruct() {
 	if config.OptionsName != "" {
 		// Option Name fix
 		opt.Name = strings.Title(config.OptionsName)
@@ -406,4 +574,17 @@ func (opt *optionStruct) fixStruct() {
 			f.Name = strings.Title(f.Name)
 		}
 	}
+	for _, f := range opt.Fields {
+		if f.IsDeprecated() || len(f.RenamedFrom) > 0 {
+			opt.HasDeprecated = true
+		}
+	}
+	// fields maybe duplicate by extends
+	names := make(map[string]struct{}, len(opt.Fields))
+	for _, f := range opt.Fields {
+		if _, ok := names[f.Name]; ok {
+			log.Fatalf("%s field %s duplicate", opt.FromFunc, f.Name)
+		}
+		names[f.Name] = struct{}{}
+	}
 }
@@ -0,0 +1,58 @@
+package option
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aggronmagi/gogen/internal/gentest"
+	"github.com/spf13/cobra"
+)
+
+var defaultConfig = config
+
+func TestGenerate(t *testing.T) {
+	cases := []gentest.Case{
+		{Dir: "shared"},
+		{Dir: "deprecated"},
+		{Dir: "atomic"},
+		{Dir: "generic"},
+		{Dir: "builder"},
+		{Dir: "interface"},
+		{Dir: "config"},
+		{Dir: "custom"},
+		{Dir: "model"},
+		{Dir: "markdown"},
+		{Dir: "html"},
+		{Dir: "gentest"},
+	}
+	for _, c := range cases {
+		t.Run(c.Dir, func(t *testing.T) {
+			c.Run(t, func(args []string) {
+				runCommand(t, args)
+			})
+		})
+	}
+}
+
+// runCommand run option command with default config, dumped model is written to model.json
+func runCommand(t *testing.T, args []string) {
+	config = defaultConfig
+	cmd := &cobra.Command{Use: "option"}
+	FlagSet(cmd.Flags())
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	if config.DumpModel {
+		fd, err := os.Create("model.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdout := os.Stdout
+		os.Stdout = fd
+		defer func() {
+			os.Stdout = stdout
+			fd.Close()
+		}()
+	}
+	RunCommand(cmd, cmd.Flags().Args()...)
+}
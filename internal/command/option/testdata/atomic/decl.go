@@ -0,0 +1,11 @@
+package atomic
+
+import "time"
+
+//go:generate gogen option --atomic
+func ServerOptionsDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Timeout": time.Duration(time.Second), // request timeout
+		"Workers": 4,
+	}
+}
@@ -0,0 +1,13 @@
+package builder
+
+//go:generate gogen option -t builder -a
+func ClientOptionsDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		// server addresses
+		"Addrs": []string{"127.0.0.1:80"}, // host:port
+		// gogen:deprecated=use Addrs
+		"Host":   "",
+		"Labels": map[string]string(nil),
+		"Port":   80, // listen port
+	}
+}
@@ -0,0 +1,11 @@
+package custom
+
+//go:generate gogen option -t fields.tmpl -o gen_fields.go
+func ClientOptionsDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		// server addresses
+		"Addrs":  []string{"127.0.0.1:80"},
+		"Labels": map[string]string(nil),
+		"Port":   80, // listen port
+	}
+}
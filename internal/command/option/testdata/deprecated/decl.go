@@ -0,0 +1,13 @@
+package deprecated
+
+//go:generate gogen option -a
+func ClientOptionsDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		// server addresses
+		// gogen:renamed-from=Addr
+		"Addrs": []string{"127.0.0.1:80"},
+		// gogen:deprecated=use Addrs
+		"Host": "",
+		"Port": 80, // listen port
+	}
+}
@@ -0,0 +1,10 @@
+package generic
+
+//go:generate gogen option -a
+func CacheOptionDeclareWithDefault[K comparable, V any]() interface{} {
+	return map[string]interface{}{
+		"Data":     map[K]V(nil),
+		"Keys":     []K(nil),
+		"Capacity": 16,
+	}
+}
@@ -0,0 +1,15 @@
+package generic
+
+import "testing"
+
+func TestWatchDogPerInstantiation(t *testing.T) {
+	var ints, strs int
+	InstallCacheOptionsWatchDog(func(cc *CacheOptions[int, int]) { ints++ })
+	InstallCacheOptionsWatchDog(func(cc *CacheOptions[string, int]) { strs++ })
+	NewCacheOptions[int, int]()
+	NewCacheOptions[string, int]()
+	NewCacheOptions[string, bool]()
+	if ints != 1 || strs != 1 {
+		t.Fatal(ints, strs)
+	}
+}
@@ -0,0 +1,13 @@
+package gentest
+
+import "time"
+
+//go:generate gogen option -a --gen-test
+func ClientOptionsDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Addrs":   []string{"127.0.0.1:80"},
+		"Port":    80,
+		"Timeout": time.Duration(time.Second),
+		"Labels":  map[string]string{"app": "client"},
+	}
+}
@@ -0,0 +1,11 @@
+package model
+
+//go:generate gogen option --dump-model
+func ClientOptionsDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		// server addresses
+		// gogen:renamed-from=Addr
+		"Addrs": []string{"127.0.0.1:80"},
+		"Port":  80, // listen port
+	}
+}
@@ -0,0 +1,19 @@
+package shared
+
+import "time"
+
+//go:generate gogen option --all
+func RetryOptionDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Retries": 3,
+		"Timeout": time.Duration(time.Second),
+	}
+}
+
+func ClientOptionDeclareWithDefault() interface{} {
+	return map[string]interface{}{
+		"Name": "client",
+		// extends retry option fields
+		"Retry": RetryOptionDeclareWithDefault(),
+	}
+}
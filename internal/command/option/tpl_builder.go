@@ -0,0 +1,66 @@
+package option
+
+const tplBuilder = `{{ template "header" . }}{{ $obj := . }}
+{{ template "struct" . }}
+// {{ .Name }}Builder build {{ .Name }} step by step.
+type {{ .Name }}Builder{{ .TypeParams }} struct {
+	cc *{{ .Name }}{{ .TypeArgs }}
+}
+
+// New{{ .Name }}Builder create builder with default value.
+func New{{ .Name }}Builder{{ .TypeParams }}() *{{ .Name }}Builder{{ .TypeArgs }} {
+	return &{{ .Name }}Builder{{ .TypeArgs }}{cc: newDefault{{ .Name }}{{ .TypeArgs }}()}
+}
+
+// Build return a copy of building options. slice and map fields are copied,
+// their elements are not.
+func (b *{{ .Name }}Builder{{ .TypeArgs }}) Build() *{{ .Name }}{{ .TypeArgs }} {
+	cc := *b.cc
+	{{- range $i,$field := .Fields }}
+	{{- if IsSlice $field.Type }}
+	cc.{{ $field.Name }} = append(b.cc.{{ $field.Name }}[:0:0], b.cc.{{ $field.Name }}...)
+	{{- else if IsMap $field.Type }}
+	if b.cc.{{ $field.Name }} != nil {
+		cc.{{ $field.Name }} = make({{ $field.Type }}, len(b.cc.{{ $field.Name }}))
+		for k, v := range b.cc.{{ $field.Name }} {
+			cc.{{ $field.Name }}[k] = v
+		}
+	}
+	{{- end }}
+	{{- end }}
+	return &cc
+}
+{{ range $i,$field := .Fields }}
+{{ DocDeprecated $field.Document $field.Deprecated }} func (b *{{ $obj.Name }}Builder{{ $obj.TypeArgs }}) {{ $field.GenFuncName $obj.OptionName }}(v {{if $field.IsSlice }}...{{$field.SliceType}}{{else}}{{$field.Type}}{{end}}) *{{ $obj.Name }}Builder{{ $obj.TypeArgs }} {
+	{{- if $field.IsDeprecated }}
+	if deprecatedHook{{ $obj.Name }} != nil {
+		deprecatedHook{{ $obj.Name }}("{{ $field.GenFuncName $obj.OptionName }}", {{ printf "%q" $field.Deprecated }})
+	}
+	{{- end }}
+	b.cc.{{ $field.Name }} = v
+	return b
+}
+{{ if and $field.IsSlice $obj.GenAppend }}
+func (b *{{ $obj.Name }}Builder{{ $obj.TypeArgs }}) {{ $field.AppendFuncName $obj.OptionName }}(v ...{{ $field.SliceType }}) *{{ $obj.Name }}Builder{{ $obj.TypeArgs }} {
+	new := make([]{{ $field.SliceType }}, 0, len(v)+len(b.cc.{{ $field.Name }}))
+	new = append(new, b.cc.{{ $field.Name }}...)
+	new = append(new, v...)
+	b.cc.{{ $field.Name }} = new
+	return b
+}
+{{ end }}
+{{- range $old := $field.RenamedFrom }}
+// {{ $field.RenamedFuncName $old $obj.OptionName }} renamed to {{ $field.GenFuncName $obj.OptionName }}.
+//
+// Deprecated: use {{ $field.GenFuncName $obj.OptionName }} instead.
+func (b *{{ $obj.Name }}Builder{{ $obj.TypeArgs }}) {{ $field.RenamedFuncName $old $obj.OptionName }}(v {{if $field.IsSlice }}...{{$field.SliceType}}{{else}}{{$field.Type}}{{end}}) *{{ $obj.Name }}Builder{{ $obj.TypeArgs }} {
+	if deprecatedHook{{ $obj.Name }} != nil {
+		deprecatedHook{{ $obj.Name }}("{{ $field.RenamedFuncName $old $obj.OptionName }}", "use {{ $field.GenFuncName $obj.OptionName }} instead")
+	}
+	return b.{{ $field.GenFuncName $obj.OptionName }}(v{{if $field.IsSlice }}...{{end}})
+}
+{{ end }}
+{{ end }}
+{{ template "deprecated-hook" . }}
+{{- template "default" . }}
+`
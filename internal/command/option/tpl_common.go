@@ -0,0 +1,55 @@
+package option
+
+// tplCommon common blocks shared by built-in templates.
+const tplCommon = `
+{{- define "header" -}}
+// Code generated by "gogen option"; DO NOT EDIT.
+// Exec: "gogen {{.ExecArgs}}"
+// Version: {{.Version}}
+
+package {{.PackageName}}
+
+$Import-Package$
+
+{{ if .TypeParams -}}
+func _{{ .TypeParams }}() {
+	_ = {{ .FromFunc }}{{ .TypeArgs }}()
+}
+{{- else -}}
+var _ = {{ .FromFunc}}()
+{{- end }}
+{{ end }}
+
+{{- define "struct" }}
+{{Doc .Document}} type {{.Name}}{{ .TypeParams }} struct { {{ range $i,$field := .Fields }}
+	{{ DocDeprecated $field.Document $field.Deprecated }} {{ $field.Name }} {{ $field.Type }} {{TailDoc $field.Comment}} {{ end }}
+}
+{{ end }}
+
+{{- define "deprecated-hook" }}
+{{- if .HasDeprecated }}
+// Install{{ .Name }}DeprecatedHook install hook, called when deprecated option function used.
+func Install{{ .Name }}DeprecatedHook(hook func(name, message string)) {
+	deprecatedHook{{ .Name }} = hook
+}
+
+var deprecatedHook{{ .Name }} func(name, message string)
+{{ end }}
+{{- end }}
+
+{{- define "default" }}
+// newDefault{{ .Name }} new option with default value
+func newDefault{{ .Name }}{{ .TypeParams }}() *{{ .Name }}{{ .TypeArgs }} {
+	cc := &{{ .Name }}{{ .TypeArgs }}{
+{{ range $i,$field := .Fields -}}
+	{{ if eq $field.FieldType 0 -}}
+		{{ $field.Name }} : {{ $field.Type }} {{ $field.Body }},
+	{{ else -}}
+		{{ $field.Name }} : {{ $field.Body }},
+	{{ end -}}
+{{ end }}
+	}
+	return cc
+}
+{{ end }}
+`
@@ -0,0 +1,16 @@
+package option
+
+// tplConfig plain config struct style.
+const tplConfig = `{{ template "header" . }}
+{{ template "struct" . }}
+// New{{ .Name }} create config with default value.
+func New{{ .Name }}{{ .TypeParams }}() *{{ .Name }}{{ .TypeArgs }} {
+	return newDefault{{ .Name }}{{ .TypeArgs }}()
+}
+
+// Defaults reset all fields to default value.
+func (cc *{{ .Name }}{{ .TypeArgs }}) Defaults() {
+	*cc = *newDefault{{ .Name }}{{ .TypeArgs }}()
+}
+{{ template "default" . }}
+`
@@ -0,0 +1,41 @@
+package option
+
+// tplMarkdown markdown options reference
+const tplMarkdown = `<!-- Code generated by "gogen {{ .ExecArgs }}"; DO NOT EDIT. -->
+{{- $obj := . }}
+
+# {{ .Name }}
+{{ if .Document }}
+{{ .Document | OneLine }}
+{{ end }}
+| Option | Setting Function | Type | Default | Description |
+|--------|------------------|------|---------|-------------|
+{{- range $i,$field := .Fields }}
+| {{ $field.Name }} | ` + "`{{ $field.GenFuncName $obj.OptionName }}`" + ` | ` + "`{{ MarkdownCell $field.Type }}`" + ` | {{ if eq $field.FieldType 0 }}function literal{{ else }}` + "`{{ MarkdownCell $field.Body }}`" + `{{ end }} | {{ MarkdownCell $field.Description }} |
+{{- end }}
+`
+
+// tplHTML html options reference
+const tplHTML = `<!-- Code generated by "gogen {{ .ExecArgs }}"; DO NOT EDIT. -->
+{{- $obj := . }}
+<h1>{{ .Name | html }}</h1>
+{{- if .Document }}
+<p>{{ .Document | OneLine | html }}</p>
+{{- end }}
+<table>
+  <thead>
+    <tr><th>Option</th><th>Setting Function</th><th>Type</th><th>Default</th><th>Description</th></tr>
+  </thead>
+  <tbody>
+{{- range $i,$field := .Fields }}
+    <tr>
+      <td>{{ $field.Name | html }}</td>
+      <td><code>{{ $field.GenFuncName $obj.OptionName | html }}</code></td>
+      <td><code>{{ $field.Type | html }}</code></td>
+      <td>{{ if eq $field.FieldType 0 }}function literal{{ else }}<code>{{ $field.Body | html }}</code>{{ end }}</td>
+      <td>{{ $field.Description | html | Replace "\n" "<br>" }}</td>
+    </tr>
+{{- end }}
+  </tbody>
+</table>
+`
@@ -0,0 +1,148 @@
+package option
+
+// tplOptionTest unit test of option template
+const tplOptionTest = `// Code generated by "gogen option"; DO NOT EDIT.
+// Exec: "gogen {{.ExecArgs}}"
+// Version: {{.Version}}
+
+package {{.PackageName}}
+
+$Import-Package$
+{{ Import "reflect" }}{{ Import "testing" }}
+{{- $obj := . }}
+func Test{{ .Name }}Default(t *testing.T) {
+	cc := newDefault{{ .Name }}()
+{{- range $i,$field := .Fields }}
+	{{- $verb := "%v" }}{{ if IsFunc $field.Type }}{{ $verb = "%p" }}{{ end }}
+	{{- if eq $field.FieldType 1 }}
+	if want := {{ $field.FromFunc }}().(map[string]interface{})[{{ Quote $field.Key }}]; !testEqual{{ $obj.Name }}(cc.{{ $field.Name }}, want) {
+		t.Errorf("{{ $field.Name }} default {{ $verb }}, declared {{ $verb }}", cc.{{ $field.Name }}, want)
+	}
+	{{- end }}
+{{- end }}
+}
+
+func Test{{ .Name }}Setting(t *testing.T) {
+{{- range $i,$field := .Fields }}
+	{{- $verb := "%v" }}{{ if IsFunc $field.Type }}{{ $verb = "%p" }}{{ end }}
+	t.Run("{{ $field.GenFuncName $obj.OptionName }}", func(t *testing.T) {
+		cc := newDefault{{ $obj.Name }}()
+		previous := cc.{{ $field.Name }}
+		var v {{ $field.Type }}
+		testValue{{ $obj.Name }}(reflect.ValueOf(&v).Elem(), reflect.ValueOf(&previous).Elem())
+		restore := cc.GetSetOption({{ $field.GenFuncName $obj.OptionName }}(v{{ if $field.IsSlice }}...{{ end }}))
+		if !testEqual{{ $obj.Name }}(cc.{{ $field.Name }}, v) {
+			t.Errorf("{{ $field.Name }} not set, got {{ $verb }} want {{ $verb }}", cc.{{ $field.Name }}, v)
+		}
+		cc.SetOption(restore)
+		if !testEqual{{ $obj.Name }}(cc.{{ $field.Name }}, previous) {
+			t.Errorf("{{ $field.Name }} not restore, got {{ $verb }} want {{ $verb }}", cc.{{ $field.Name }}, previous)
+		}
+	})
+	{{- if and $field.IsSlice $obj.GenAppend }}
+	t.Run("{{ $field.AppendFuncName $obj.OptionName }}", func(t *testing.T) {
+		cc := newDefault{{ $obj.Name }}()
+		previous := cc.{{ $field.Name }}
+		var v {{ $field.Type }}
+		testValue{{ $obj.Name }}(reflect.ValueOf(&v).Elem(), reflect.ValueOf(&v).Elem())
+		want := append(append({{ $field.Type }}{}, previous...), v...)
+		restore := cc.GetSetOption({{ $field.AppendFuncName $obj.OptionName }}(v...))
+		if !testEqual{{ $obj.Name }}(cc.{{ $field.Name }}, want) {
+			t.Errorf("{{ $field.Name }} not append, got {{ $verb }} want {{ $verb }}", cc.{{ $field.Name }}, want)
+		}
+		cc.SetOption(restore)
+		if !testEqual{{ $obj.Name }}(cc.{{ $field.Name }}, previous) {
+			t.Errorf("{{ $field.Name }} not restore, got {{ $verb }} want {{ $verb }}", cc.{{ $field.Name }}, previous)
+		}
+	})
+	{{- end }}
+{{- end }}
+}
+
+// testValue{{ .Name }} set v to a value different from cur
+func testValue{{ .Name }}(v, cur reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(!cur.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(cur.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(cur.Uint() + 1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(cur.Float() + 1)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(cur.Complex() + 1)
+	case reflect.String:
+		v.SetString(cur.String() + "-test")
+	case reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), cur.Len()+1, cur.Len()+1)
+		reflect.Copy(s, cur)
+		testValue{{ .Name }}(s.Index(cur.Len()), reflect.Zero(v.Type().Elem()))
+		v.Set(s)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		iter := cur.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(iter.Key(), iter.Value())
+		}
+		key := reflect.New(v.Type().Key()).Elem()
+		testValue{{ .Name }}(key, reflect.Zero(key.Type()))
+		value := reflect.New(v.Type().Elem()).Elem()
+		testValue{{ .Name }}(value, reflect.Zero(value.Type()))
+		m.SetMapIndex(key, value)
+		v.Set(m)
+	case reflect.Ptr:
+		p := reflect.New(v.Type().Elem())
+		elem := reflect.Zero(p.Elem().Type())
+		if !cur.IsNil() {
+			elem = cur.Elem()
+		}
+		testValue{{ .Name }}(p.Elem(), elem)
+		v.Set(p)
+	case reflect.Array:
+		v.Set(cur)
+		if v.Len() > 0 {
+			testValue{{ .Name }}(v.Index(0), cur.Index(0))
+		}
+	case reflect.Chan:
+		v.Set(reflect.MakeChan(v.Type(), 1))
+	case reflect.Func:
+		typ := v.Type()
+		v.Set(reflect.MakeFunc(typ, func([]reflect.Value) []reflect.Value {
+			out := make([]reflect.Value, typ.NumOut())
+			for k := range out {
+				out[k] = reflect.Zero(typ.Out(k))
+			}
+			return out
+		}))
+	case reflect.Interface:
+		if s := reflect.ValueOf("test"); s.Type().AssignableTo(v.Type()) {
+			v.Set(s)
+		}
+	}
+}
+
+// testEqual{{ .Name }} compare option value. nil and empty slice or map are equal,
+// function compare by code pointer, declared constant convert to field type.
+func testEqual{{ .Name }}(got, want interface{}) bool {
+	if reflect.DeepEqual(got, want) {
+		return true
+	}
+	gv, wv := reflect.ValueOf(got), reflect.ValueOf(want)
+	if !gv.IsValid() || !wv.IsValid() {
+		return false
+	}
+	switch gv.Kind() {
+	case reflect.Func:
+		return wv.Kind() == reflect.Func && gv.Pointer() == wv.Pointer()
+	case reflect.Slice, reflect.Map:
+		if wv.Kind() == gv.Kind() && gv.Len() == 0 && wv.Len() == 0 {
+			return true
+		}
+	}
+	if wv.Type().ConvertibleTo(gv.Type()) {
+		return reflect.DeepEqual(got, wv.Convert(gv.Type()).Interface())
+	}
+	return false
+}
+`
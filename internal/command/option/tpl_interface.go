@@ -0,0 +1,65 @@
+package option
+
+// tplInterface option interface style, compatible with grpc-go and zap.
+const tplInterface = `{{ template "header" . }}{{ $obj := . }}
+{{ template "struct" . }}
+// {{ .OptionName }} configures {{ .Name }}.
+type {{ .OptionName }}{{ .TypeParams }} interface {
+	apply(cc *{{ .Name }}{{ .TypeArgs }})
+}
+
+// func{{ .OptionName }} wraps a function that modifies {{ .Name }} into an implementation of the {{ .OptionName }} interface.
+type func{{ .OptionName }}{{ .TypeParams }} func(cc *{{ .Name }}{{ .TypeArgs }})
+
+func (f func{{ .OptionName }}{{ .TypeArgs }}) apply(cc *{{ .Name }}{{ .TypeArgs }}) {
+	f(cc)
+}
+{{ range $i,$field := .Fields }}
+{{ DocDeprecated $field.Document $field.Deprecated }} func {{ $field.GenFuncName $obj.OptionName }}{{ $obj.TypeParams }}(v {{if $field.IsSlice }}...{{$field.SliceType}}{{else}}{{$field.Type}}{{end}}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+	{{- if $field.IsDeprecated }}
+	if deprecatedHook{{ $obj.Name }} != nil {
+		deprecatedHook{{ $obj.Name }}("{{ $field.GenFuncName $obj.OptionName }}", {{ printf "%q" $field.Deprecated }})
+	}
+	{{- end }}
+	return func{{ $obj.OptionName }}{{ $obj.TypeArgs }}(func(cc *{{ $obj.Name }}{{ $obj.TypeArgs }}) {
+		cc.{{ $field.Name }} = v
+	})
+}
+{{ if and $field.IsSlice $obj.GenAppend }}
+func {{ $field.AppendFuncName $obj.OptionName }}{{ $obj.TypeParams }}(v ...{{ $field.SliceType }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+	return func{{ $obj.OptionName }}{{ $obj.TypeArgs }}(func(cc *{{ $obj.Name }}{{ $obj.TypeArgs }}) {
+		new := make([]{{ $field.SliceType }}, 0, len(v)+len(cc.{{ $field.Name }}))
+		new = append(new, cc.{{ $field.Name }}...)
+		new = append(new, v...)
+		cc.{{ $field.Name }} = new
+	})
+}
+{{ end }}
+{{- range $old := $field.RenamedFrom }}
+// {{ $field.RenamedFuncName $old $obj.OptionName }} renamed to {{ $field.GenFuncName $obj.OptionName }}.
+//
+// Deprecated: use {{ $field.GenFuncName $obj.OptionName }} instead.
+func {{ $field.RenamedFuncName $old $obj.OptionName }}{{ $obj.TypeParams }}(v {{if $field.IsSlice }}...{{$field.SliceType}}{{else}}{{$field.Type}}{{end}}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+	if deprecatedHook{{ $obj.Name }} != nil {
+		deprecatedHook{{ $obj.Name }}("{{ $field.RenamedFuncName $old $obj.OptionName }}", "use {{ $field.GenFuncName $obj.OptionName }} instead")
+	}
+	return {{ $field.GenFuncName $obj.OptionName }}{{ $obj.TypeArgs }}(v{{if $field.IsSlice }}...{{end}})
+}
+{{ end }}
+{{ end }}
+// ApplyOption modify options
+func (cc *{{ .Name }}{{ .TypeArgs }}) ApplyOption(opts ...{{ .OptionName }}{{ .TypeArgs }}) {
+	for _, opt := range opts {
+		opt.apply(cc)
+	}
+}
+
+// New{{ .Name }} create options instance.
+func New{{ .Name }}{{ .TypeParams }}(opts ...{{ .OptionName }}{{ .TypeArgs }}) *{{ .Name }}{{ .TypeArgs }} {
+	cc := newDefault{{ .Name }}{{ .TypeArgs }}()
+	cc.ApplyOption(opts...)
+	return cc
+}
+{{ template "deprecated-hook" . }}
+{{- template "default" . }}
+`
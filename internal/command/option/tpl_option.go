@@ -1,92 +1,180 @@
 package option
 
-const tplOption = `// Code generated by "gogen option"; DO NOT EDIT.
-// Exec: "gogen {{.ExecArgs}}" {{ $obj := . }}
-// Version: {{.Version}}
-
-package {{.PackageName}}
-
-$Import-Package$
-
-var _ = {{ .FromFunc}}()
-
-{{Doc .Document}} type {{.Name}} struct { {{ range $i,$field := .Fields }}
-	{{ Doc $field.Document }} {{ $field.Name }} {{ $field.Type }} {{TailDoc $field.Comment}} {{ end }}
-}
-
+const tplOption = `{{ template "header" . }}{{ $obj := . }}
+{{ template "struct" . }}
 {{ range $i,$field := .Fields }}
-{{ Doc $field.Document }} func {{ $field.GenFuncName $obj.OptionName }}(v {{if $field.IsSlice }}...{{$field.SliceType}}{{else}}{{$field.Type}}{{end}}) {{ $obj.OptionName }} {
-	return func(cc *{{ $obj.Name }}) {{ $obj.OptionName }} {
+{{ DocDeprecated $field.Document $field.Deprecated }} func {{ $field.GenFuncName $obj.OptionName }}{{ $obj.TypeParams }}(v {{if $field.IsSlice }}...{{$field.SliceType}}{{else}}{{$field.Type}}{{end}}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+	{{- if $field.IsDeprecated }}
+	if deprecatedHook{{ $obj.Name }} != nil {
+		deprecatedHook{{ $obj.Name }}("{{ $field.GenFuncName $obj.OptionName }}", {{ printf "%q" $field.Deprecated }})
+	}
+	// restore option assign previous value directly, not call hook again
+	var set func(v {{ $field.Type }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }}
+	set = func(v {{ $field.Type }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+		return func(cc *{{ $obj.Name }}{{ $obj.TypeArgs }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+			previous := cc.{{ $field.Name }}
+			cc.{{ $field.Name }} = v
+			return set(previous)
+		}
+	}
+	return set(v)
+	{{- else }}
+	return func(cc *{{ $obj.Name }}{{ $obj.TypeArgs }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
 		previous := cc.{{ $field.Name }}
 		cc.{{ $field.Name }} = v
-		return {{ $field.GenFuncName $obj.OptionName }}(previous{{if $field.IsSlice }}...{{end}})
+		return {{ $field.GenFuncName $obj.OptionName }}{{ $obj.TypeArgs }}(previous{{if $field.IsSlice }}...{{end}})
 	}
+	{{- end }}
 }
 {{ if and $field.IsSlice $obj.GenAppend }}
-func {{ $field.AppendFuncName $obj.OptionName }}(v ...{{ $field.SliceType }}) {{ $obj.OptionName }} {
-	return func(cc *{{ $obj.Name }}) {{ .OptionName }} {
+func {{ $field.AppendFuncName $obj.OptionName }}{{ $obj.TypeParams }}(v ...{{ $field.SliceType }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+	{{- if $field.IsDeprecated }}
+	// restore option assign previous value directly, not call hook of {{ $field.GenFuncName $obj.OptionName }}
+	var set func(v {{ $field.Type }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }}
+	set = func(v {{ $field.Type }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+		return func(cc *{{ $obj.Name }}{{ $obj.TypeArgs }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+			previous := cc.{{ $field.Name }}
+			cc.{{ $field.Name }} = v
+			return set(previous)
+		}
+	}
+	{{- end }}
+	return func(cc *{{ $obj.Name }}{{ $obj.TypeArgs }}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
 		previous := cc.{{ $field.Name }}
   		new := make([]{{ $field.SliceType }},0,len(v)+len(previous))
   		new = append(new, previous...)
   		new = append(new, v...)
 		cc.{{ $field.Name }} = new
-		return {{ $field.AppendFuncName $obj.OptionName }}(previous...)
+		{{- if $field.IsDeprecated }}
+		return set(previous)
+		{{- else }}
+		return {{ $field.GenFuncName $obj.OptionName }}{{ $obj.TypeArgs }}(previous...)
+		{{- end }}
+	}
+}
+{{ end }}
+{{- range $old := $field.RenamedFrom }}
+// {{ $field.RenamedFuncName $old $obj.OptionName }} renamed to {{ $field.GenFuncName $obj.OptionName }}.
+//
+// Deprecated: use {{ $field.GenFuncName $obj.OptionName }} instead.
+func {{ $field.RenamedFuncName $old $obj.OptionName }}{{ $obj.TypeParams }}(v {{if $field.IsSlice }}...{{$field.SliceType}}{{else}}{{$field.Type}}{{end}}) {{ $obj.OptionName }}{{ $obj.TypeArgs }} {
+	if deprecatedHook{{ $obj.Name }} != nil {
+		deprecatedHook{{ $obj.Name }}("{{ $field.RenamedFuncName $old $obj.OptionName }}", "use {{ $field.GenFuncName $obj.OptionName }} instead")
 	}
+	return {{ $field.GenFuncName $obj.OptionName }}{{ $obj.TypeArgs }}(v{{if $field.IsSlice }}...{{end}})
 }
 {{ end }}
 {{ end }}
 
 // SetOption modify options
-func (cc *{{ .Name }}) SetOption(opt {{ .OptionName }}) {
+func (cc *{{ .Name }}{{ .TypeArgs }}) SetOption(opt {{ .OptionName }}{{ .TypeArgs }}) {
 	_ = opt(cc)
 }
 
 // ApplyOption modify options
-func (cc *{{ .Name }}) ApplyOption(opts... {{ .OptionName }}) {
+func (cc *{{ .Name }}{{ .TypeArgs }}) ApplyOption(opts... {{ .OptionName }}{{ .TypeArgs }}) {
 	for _, opt := range opts  {
 		_ = opt(cc)
 	}
 }
 
 // GetSetOption modify and get last option
-func (cc *{{ .Name }}) GetSetOption(opt {{ .OptionName }}) {{ .OptionName }} {
+func (cc *{{ .Name }}{{ .TypeArgs }}) GetSetOption(opt {{ .OptionName }}{{ .TypeArgs }}) {{ .OptionName }}{{ .TypeArgs }} {
 	return opt(cc)
 }
 
 // {{ .OptionName }} option define 
-type {{ .OptionName }} func(cc *{{ .Name }}) {{ .OptionName }}
+type {{ .OptionName }}{{ .TypeParams }} func(cc *{{ .Name }}{{ .TypeArgs }}) {{ .OptionName }}{{ .TypeArgs }}
 
 // New{{ .Name }} create options instance.
-func New{{ .Name }}(opts ... {{ .OptionName }}) *{{ .Name }} {
-	cc := newDefault{{ .Name }}()
+func New{{ .Name }}{{ .TypeParams }}(opts ... {{ .OptionName }}{{ .TypeArgs }}) *{{ .Name }}{{ .TypeArgs }} {
+	cc := newDefault{{ .Name }}{{ .TypeArgs }}()
 	for _, opt := range opts  {
 		_ = opt(cc)
 	}
-	if watchDog{{ .Name }} != nil {
-		watchDog{{ .Name }}(cc)
-	}
+	{{- template "watchdog" . }}
 	return cc
 }
 
+{{- if .TypeParams }}{{ Import "reflect" }}{{ Import "sync" }}
+// Install{{ .Name }}WatchDog install watch dog of instantiated type, each instantiation has its own watch dog.
+func Install{{ .Name }}WatchDog{{ .TypeParams }}(dog func(cc *{{ .Name }}{{ .TypeArgs }})) {
+	watchDog{{ .Name }}.Lock()
+	defer watchDog{{ .Name }}.Unlock()
+	watchDog{{ .Name }}.dogs[reflect.TypeOf(dog)] = dog
+}
+
+// watchDog{{ .Name }} generic watch dogs, keyed by func type of instantiation.
+var watchDog{{ .Name }} = struct {
+	sync.RWMutex
+	dogs map[reflect.Type]interface{}
+}{dogs: make(map[reflect.Type]interface{})}
+{{- else }}
 // Install{{ .Name }}WatchDog install watch dog
 func Install{{ .Name }}WatchDog(dog func(cc *{{ .Name }})) {
 	watchDog{{ .Name }} = dog
 }
 
 var watchDog{{ .Name }} func(cc *{{ .Name }})
+{{- end }}
+{{ define "watchdog" }}
+	{{- if .TypeParams }}
+	watchDog{{ .Name }}.RLock()
+	dog, ok := watchDog{{ .Name }}.dogs[reflect.TypeOf((func(cc *{{ .Name }}{{ .TypeArgs }}))(nil))].(func(cc *{{ .Name }}{{ .TypeArgs }}))
+	watchDog{{ .Name }}.RUnlock()
+	if ok {
+		dog(cc)
+	}
+	{{- else }}
+	if watchDog{{ .Name }} != nil {
+		watchDog{{ .Name }}(cc)
+	}
+	{{- end }}
+{{- end }}
+{{ template "deprecated-hook" . }}
+{{- if .GenAtomic }}{{ Import "sync" }}{{ Import "sync/atomic" }}
+// {{ .Name }}Holder hold {{ .Name }} behind atomic pointer, safe for concurrent use.
+type {{ .Name }}Holder{{ .TypeParams }} struct {
+	value       atomic.Pointer[{{ .Name }}{{ .TypeArgs }}]
+	mutex       sync.Mutex
+	subscribers []func(old, new *{{ .Name }}{{ .TypeArgs }})
+}
 
-// newDefault{{ .Name }} new option with default value
-func newDefault{{ .Name }} () *{{ .Name }} {
-	cc := &{{ .Name }}{
-{{ range $i,$field := .Fields -}}
-	{{ if eq $field.FieldType 0 -}}
-		{{ $field.Name }} : {{ $field.Type }} {{ $field.Body }},
-	{{ else -}}
-		{{ $field.Name }} : {{ $field.Body }},
-	{{ end -}}
-{{ end }}
+// New{{ .Name }}Holder create holder with options.
+func New{{ .Name }}Holder{{ .TypeParams }}(opts ...{{ .OptionName }}{{ .TypeArgs }}) *{{ .Name }}Holder{{ .TypeArgs }} {
+	h := &{{ .Name }}Holder{{ .TypeArgs }}{}
+	h.value.Store(New{{ .Name }}{{ .TypeArgs }}(opts...))
+	return h
+}
+
+// Load get current options. the returned value must not be modified.
+func (h *{{ .Name }}Holder{{ .TypeArgs }}) Load() *{{ .Name }}{{ .TypeArgs }} {
+	return h.value.Load()
+}
+
+// Update apply options on a copy of current options, then swap it in and
+// notify subscribers. subscribers must not call Update.
+func (h *{{ .Name }}Holder{{ .TypeArgs }}) Update(opts ...{{ .OptionName }}{{ .TypeArgs }}) *{{ .Name }}{{ .TypeArgs }} {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	old := h.value.Load()
+	cc := new({{ .Name }}{{ .TypeArgs }})
+	*cc = *old
+	cc.ApplyOption(opts...)
+	{{- template "watchdog" . }}
+	h.value.Store(cc)
+	for _, f := range h.subscribers {
+		f(old, cc)
 	}
 	return cc
 }
 
+// Subscribe register options change notify func.
+func (h *{{ .Name }}Holder{{ .TypeArgs }}) Subscribe(f func(old, new *{{ .Name }}{{ .TypeArgs }})) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.subscribers = append(h.subscribers, f)
+}
+{{ end }}
+{{- template "default" . }}
 `
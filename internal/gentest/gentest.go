@@ -0,0 +1,243 @@
+// Package gentest run generate command on fixtures and check output, used by tests of commands.
+package gentest
+
+import (
+	"bufio"
+	"flag"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aggronmagi/gogen/goparse"
+)
+
+var update = flag.Bool("update", false, "update golden files of generate fixtures")
+
+// Case generate fixture, a directory under testdata contains declaration go files with
+// "//go:generate gogen" directives and input files of them. every generated file is compared
+// with <file>.golden in fixture.
+type Case struct {
+	// Dir fixture directory under testdata
+	Dir string
+	// Requires modules used by generated code, eg: "github.com/spf13/viper v1.21.0"
+	Requires []string
+	// Replace replace modules used by generated code with stub modules.
+	// module path => stub module directory under testdata
+	Replace map[string]string
+	// NoCompile not compile generated code, dependency of generated code is not available
+	NoCompile bool
+}
+
+// Run copy fixture into temporary module, exec generate directives of fixture by generate,
+// compare result with golden files, then vet and test generated code.
+// generate receive command flags and arguments of directive.
+func (c Case) Run(t *testing.T, generate func(args []string)) {
+	src, err := filepath.Abs(filepath.Join("testdata", c.Dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	inputs := make(map[string]bool)
+	for _, entry := range mustReadDir(t, src) {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), ".golden") {
+			continue
+		}
+		copyFile(t, filepath.Join(src, entry.Name()), filepath.Join(dir, entry.Name()))
+		inputs[entry.Name()] = true
+	}
+	writeFile(t, filepath.Join(dir, "go.mod"), "module fixture\n\ngo 1.22\n")
+	inputs["go.mod"] = true
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	args := os.Args
+	defer func() { os.Args = args }()
+	for _, file := range sortedKeys(inputs) {
+		// input maybe removed by previous directive
+		if _, err := os.Stat(file); !strings.HasSuffix(file, ".go") || err != nil {
+			continue
+		}
+		for _, d := range directives(t, file) {
+			goparse.EnvGoFile = file
+			goparse.EnvGoLine = d.line
+			goparse.EnvGoPackage = packageName(t, file)
+			os.Args = append([]string{"gogen"}, d.args...)
+			generate(d.args[1:])
+		}
+	}
+
+	// compare with golden files
+	outputs := make(map[string]bool)
+	for _, entry := range mustReadDir(t, dir) {
+		if entry.IsDir() || inputs[entry.Name()] {
+			continue
+		}
+		outputs[entry.Name()] = true
+	}
+	if len(outputs) == 0 {
+		t.Fatalf("fixture %s generate nothing", c.Dir)
+	}
+	for _, file := range sortedKeys(outputs) {
+		golden := filepath.Join(src, file+".golden")
+		got, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *update {
+			writeFile(t, golden, string(got))
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Errorf("read golden file: %v, run go test -update to create it", err)
+			continue
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s differs from %s, run go test -update to update it\n%s", file, golden, diffLine(string(want), string(got)))
+		}
+	}
+	for _, entry := range mustReadDir(t, src) {
+		name := strings.TrimSuffix(entry.Name(), ".golden")
+		if name == entry.Name() || outputs[name] {
+			continue
+		}
+		if *update {
+			os.Remove(filepath.Join(src, entry.Name()))
+			continue
+		}
+		t.Errorf("golden file %s is not generated", entry.Name())
+	}
+
+	if c.NoCompile {
+		return
+	}
+	mod := "module fixture\n\ngo 1.22\n"
+	requires := append([]string{}, c.Requires...)
+	replaces := make([]string, 0, len(c.Replace))
+	for path, stub := range c.Replace {
+		requires = append(requires, path+" v0.0.0")
+		replaces = append(replaces, path+" => "+filepath.Join(filepath.Dir(src), stub))
+	}
+	sort.Strings(requires)
+	sort.Strings(replaces)
+	if len(requires) > 0 {
+		mod += "\nrequire (\n\t" + strings.Join(requires, "\n\t") + "\n)\n"
+	}
+	if len(replaces) > 0 {
+		mod += "\nreplace (\n\t" + strings.Join(replaces, "\n\t") + "\n)\n"
+	}
+	writeFile(t, "go.mod", mod)
+	if out, err := goCommand("mod", "tidy"); err != nil {
+		t.Skipf("dependencies of generated code not available: %v\n%s", err, out)
+	}
+	if out, err := goCommand("vet", "./..."); err != nil {
+		t.Fatalf("vet generated code: %v\n%s", err, out)
+	}
+	if out, err := goCommand("test", "./..."); err != nil {
+		t.Fatalf("test generated code: %v\n%s", err, out)
+	}
+}
+
+type directive struct {
+	line int
+	// args command and it's flags and arguments
+	args []string
+}
+
+// directives gogen go:generate directives of file
+func directives(t *testing.T, file string) (list []directive) {
+	fd, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	scanner := bufio.NewScanner(fd)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(text, goparse.GoGeneratePrefix+" gogen ") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(text, goparse.GoGeneratePrefix+" gogen "))
+		list = append(list, directive{line: line, args: fields})
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func packageName(t *testing.T, file string) string {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f.Name.Name
+}
+
+// diffLine report first different line
+func diffLine(want, got string) string {
+	wl := strings.Split(want, "\n")
+	gl := strings.Split(got, "\n")
+	for i := 0; i < len(wl) || i < len(gl); i++ {
+		var w, g string
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if w != g {
+			return "line " + strconv.Itoa(i+1) + ":\n-" + w + "\n+" + g
+		}
+	}
+	return ""
+}
+
+func goCommand(args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	return cmd.CombinedOutput()
+}
+
+func copyFile(t *testing.T, from, to string) {
+	data, err := os.ReadFile(from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, to, string(data))
+}
+
+func writeFile(t *testing.T, file, data string) {
+	if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustReadDir(t *testing.T, dir string) []os.DirEntry {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,9 +1,16 @@
 module redismock
 
-go 1.15
+go 1.23.0
 
 require (
 	bou.ke/monkey v1.0.2
 	github.com/go-redis/redis/v8 v8.11.3
-	github.com/golang/mock v1.6.0
+	go.uber.org/mock v0.6.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	golang.org/x/net v0.43.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
 )
@@ -1,6 +1,6 @@
 // Code generated by "gogen imake"; DO NOT EDIT.
 // Exec: "gogen imake github.com/go-redis/redis/v8 --to redismock -m .*Cmd$ -o samples/redismock/redis.go --mock"
-// Version: 0.0.1
+// Version: 0.0.13
 
 package redismock
 
@@ -12,279 +12,279 @@ import (
 
 type BoolCmdIFace interface {
 	baseCmdIFace
+	Val() bool
 	Result() (bool, error)
 	String() string
-	Val() bool
 }
 
 type BoolSliceCmdIFace interface {
 	baseCmdIFace
+	Val() []bool
 	Result() ([]bool, error)
 	String() string
-	Val() []bool
 }
 
 type ClusterSlotsCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.ClusterSlot
 	Result() ([]redis.ClusterSlot, error)
 	String() string
-	Val() []redis.ClusterSlot
 }
 
 type CmdIFace interface {
 	baseCmdIFace
-	Bool() (bool, error)
-	Float32() (float32, error)
-	Float64() (float64, error)
-	Int() (int, error)
-	Int64() (int64, error)
-	Result() (interface{}, error)
 	String() string
+	Val() interface{}
+	Result() (interface{}, error)
 	Text() (string, error)
+	Int() (int, error)
+	Int64() (int64, error)
 	Uint64() (uint64, error)
-	Val() interface{}
+	Float32() (float32, error)
+	Float64() (float64, error)
+	Bool() (bool, error)
 }
 
 type CommandsInfoCmdIFace interface {
 	baseCmdIFace
+	Val() map[string]*redis.CommandInfo
 	Result() (map[string]*redis.CommandInfo, error)
 	String() string
-	Val() map[string]*redis.CommandInfo
 }
 
 type DurationCmdIFace interface {
 	baseCmdIFace
+	Val() time.Duration
 	Result() (time.Duration, error)
 	String() string
-	Val() time.Duration
 }
 
 type FloatCmdIFace interface {
 	baseCmdIFace
+	Val() float64
 	Result() (float64, error)
 	String() string
-	Val() float64
 }
 
 type FloatSliceCmdIFace interface {
 	baseCmdIFace
+	Val() []float64
 	Result() ([]float64, error)
 	String() string
-	Val() []float64
 }
 
 type GeoLocationCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.GeoLocation
 	Result() ([]redis.GeoLocation, error)
 	String() string
-	Val() []redis.GeoLocation
 }
 
 type GeoPosCmdIFace interface {
 	baseCmdIFace
+	Val() []*redis.GeoPos
 	Result() ([]*redis.GeoPos, error)
 	String() string
-	Val() []*redis.GeoPos
 }
 
 type GeoSearchLocationCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.GeoLocation
 	Result() ([]redis.GeoLocation, error)
 	String() string
-	Val() []redis.GeoLocation
 }
 
 type IntCmdIFace interface {
 	baseCmdIFace
+	Val() int64
 	Result() (int64, error)
-	String() string
 	Uint64() (uint64, error)
-	Val() int64
+	String() string
 }
 
 type IntSliceCmdIFace interface {
 	baseCmdIFace
+	Val() []int64
 	Result() ([]int64, error)
 	String() string
-	Val() []int64
 }
 
 type ScanCmdIFace interface {
 	baseCmdIFace
-	// Iterator creates a new ScanIterator.
-	Iterator() *redis.ScanIterator
+	Val() (keys []string, cursor uint64)
 	Result() (keys []string, cursor uint64, err error)
 	String() string
-	Val() (keys []string, cursor uint64)
+	// Iterator creates a new ScanIterator.
+	Iterator() *redis.ScanIterator
 }
 
 type SliceCmdIFace interface {
 	baseCmdIFace
+	Val() []interface{}
 	Result() ([]interface{}, error)
+	String() string
 	// Scan scans the results from the map into a destination struct. The map keys
 	// are matched in the Redis struct fields by the `redis:"field"` tag.
 	Scan(dst interface{}) error
-	String() string
-	Val() []interface{}
 }
 
 type SlowLogCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.SlowLog
 	Result() ([]redis.SlowLog, error)
 	String() string
-	Val() []redis.SlowLog
 }
 
 type StatusCmdIFace interface {
 	baseCmdIFace
+	Val() string
 	Result() (string, error)
 	String() string
-	Val() string
 }
 
 type StringCmdIFace interface {
 	baseCmdIFace
-	Bool() (bool, error)
+	Val() string
+	Result() (string, error)
 	Bytes() ([]byte, error)
-	Float32() (float32, error)
-	Float64() (float64, error)
+	Bool() (bool, error)
 	Int() (int, error)
 	Int64() (int64, error)
-	Result() (string, error)
+	Uint64() (uint64, error)
+	Float32() (float32, error)
+	Float64() (float64, error)
+	Time() (time.Time, error)
 	Scan(val interface{}) error
 	String() string
-	Time() (time.Time, error)
-	Uint64() (uint64, error)
-	Val() string
 }
 
 type StringIntMapCmdIFace interface {
 	baseCmdIFace
+	Val() map[string]int64
 	Result() (map[string]int64, error)
 	String() string
-	Val() map[string]int64
 }
 
 type StringSliceCmdIFace interface {
 	baseCmdIFace
+	Val() []string
 	Result() ([]string, error)
-	ScanSlice(container interface{}) error
 	String() string
-	Val() []string
+	ScanSlice(container interface{}) error
 }
 
 type StringStringMapCmdIFace interface {
 	baseCmdIFace
+	Val() map[string]string
 	Result() (map[string]string, error)
+	String() string
 	// Scan scans the results from the map into a destination struct. The map keys
 	// are matched in the Redis struct fields by the `redis:"field"` tag.
 	Scan(dst interface{}) error
-	String() string
-	Val() map[string]string
 }
 
 type StringStructMapCmdIFace interface {
 	baseCmdIFace
+	Val() map[string]struct{}
 	Result() (map[string]struct{}, error)
 	String() string
-	Val() map[string]struct{}
 }
 
 type TimeCmdIFace interface {
 	baseCmdIFace
+	Val() time.Time
 	Result() (time.Time, error)
 	String() string
-	Val() time.Time
 }
 
 type XAutoClaimCmdIFace interface {
 	baseCmdIFace
+	Val() (messages []redis.XMessage, start string)
 	Result() (messages []redis.XMessage, start string, err error)
 	String() string
-	Val() (messages []redis.XMessage, start string)
 }
 
 type XAutoClaimJustIDCmdIFace interface {
 	baseCmdIFace
+	Val() (ids []string, start string)
 	Result() (ids []string, start string, err error)
 	String() string
-	Val() (ids []string, start string)
 }
 
 type XInfoConsumersCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.XInfoConsumer
 	Result() ([]redis.XInfoConsumer, error)
 	String() string
-	Val() []redis.XInfoConsumer
 }
 
 type XInfoGroupsCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.XInfoGroup
 	Result() ([]redis.XInfoGroup, error)
 	String() string
-	Val() []redis.XInfoGroup
 }
 
 type XInfoStreamCmdIFace interface {
 	baseCmdIFace
+	Val() *redis.XInfoStream
 	Result() (*redis.XInfoStream, error)
 	String() string
-	Val() *redis.XInfoStream
 }
 
 type XInfoStreamFullCmdIFace interface {
 	baseCmdIFace
+	Val() *redis.XInfoStreamFull
 	Result() (*redis.XInfoStreamFull, error)
 	String() string
-	Val() *redis.XInfoStreamFull
 }
 
 type XMessageSliceCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.XMessage
 	Result() ([]redis.XMessage, error)
 	String() string
-	Val() []redis.XMessage
 }
 
 type XPendingCmdIFace interface {
 	baseCmdIFace
+	Val() *redis.XPending
 	Result() (*redis.XPending, error)
 	String() string
-	Val() *redis.XPending
 }
 
 type XPendingExtCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.XPendingExt
 	Result() ([]redis.XPendingExt, error)
 	String() string
-	Val() []redis.XPendingExt
 }
 
 type XStreamSliceCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.XStream
 	Result() ([]redis.XStream, error)
 	String() string
-	Val() []redis.XStream
 }
 
 type ZSliceCmdIFace interface {
 	baseCmdIFace
+	Val() []redis.Z
 	Result() ([]redis.Z, error)
 	String() string
-	Val() []redis.Z
 }
 
 type ZWithKeyCmdIFace interface {
 	baseCmdIFace
+	Val() *redis.ZWithKey
 	Result() (*redis.ZWithKey, error)
 	String() string
-	Val() *redis.ZWithKey
 }
 
 type baseCmdIFace interface {
-	Args() []interface{}
-	Err() error
-	FullName() string
 	Name() string
+	FullName() string
+	Args() []interface{}
 	SetErr(e error)
+	Err() error
 }
@@ -1,15 +1,15 @@
-// Code generated by MockGen. DO NOT EDIT.
-// Source: samples/redismock/redis.go
+// Code generated by "gogen imake"; DO NOT EDIT.
+// Exec: "gogen imake github.com/go-redis/redis/v8 --to redismock -m .*Cmd$ -o samples/redismock/redis.go --mock"
+// Version: 0.0.13
 
-// Package redismock is a generated GoMock package.
 package redismock
 
 import (
-	reflect "reflect"
+	"reflect"
 	time "time"
 
 	redis "github.com/go-redis/redis/v8"
-	gomock "github.com/golang/mock/gomock"
+	"go.uber.org/mock/gomock"
 )
 
 // MockBoolCmdIFace is a mock of BoolCmdIFace interface.
@@ -107,15 +107,15 @@ func (mr *MockBoolCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockBoolCmdIFace) SetErr(e error) {
+func (m *MockBoolCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockBoolCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockBoolCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockBoolCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockBoolCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -241,15 +241,15 @@ func (mr *MockBoolSliceCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockBoolSliceCmdIFace) SetErr(e error) {
+func (m *MockBoolSliceCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockBoolSliceCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockBoolSliceCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockBoolSliceCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockBoolSliceCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -375,15 +375,15 @@ func (mr *MockClusterSlotsCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockClusterSlotsCmdIFace) SetErr(e error) {
+func (m *MockClusterSlotsCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockClusterSlotsCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockClusterSlotsCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockClusterSlotsCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockClusterSlotsCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -584,15 +584,15 @@ func (mr *MockCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockCmdIFace) SetErr(e error) {
+func (m *MockCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -748,15 +748,15 @@ func (mr *MockCommandsInfoCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockCommandsInfoCmdIFace) SetErr(e error) {
+func (m *MockCommandsInfoCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockCommandsInfoCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockCommandsInfoCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockCommandsInfoCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockCommandsInfoCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -882,15 +882,15 @@ func (mr *MockDurationCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockDurationCmdIFace) SetErr(e error) {
+func (m *MockDurationCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockDurationCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockDurationCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockDurationCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockDurationCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -1016,15 +1016,15 @@ func (mr *MockFloatCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockFloatCmdIFace) SetErr(e error) {
+func (m *MockFloatCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockFloatCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockFloatCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockFloatCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockFloatCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -1150,15 +1150,15 @@ func (mr *MockFloatSliceCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockFloatSliceCmdIFace) SetErr(e error) {
+func (m *MockFloatSliceCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockFloatSliceCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockFloatSliceCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockFloatSliceCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockFloatSliceCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -1284,15 +1284,15 @@ func (mr *MockGeoLocationCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockGeoLocationCmdIFace) SetErr(e error) {
+func (m *MockGeoLocationCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockGeoLocationCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockGeoLocationCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockGeoLocationCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockGeoLocationCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -1418,15 +1418,15 @@ func (mr *MockGeoPosCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockGeoPosCmdIFace) SetErr(e error) {
+func (m *MockGeoPosCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockGeoPosCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockGeoPosCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockGeoPosCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockGeoPosCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -1552,15 +1552,15 @@ func (mr *MockGeoSearchLocationCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockGeoSearchLocationCmdIFace) SetErr(e error) {
+func (m *MockGeoSearchLocationCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockGeoSearchLocationCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockGeoSearchLocationCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockGeoSearchLocationCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockGeoSearchLocationCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -1686,15 +1686,15 @@ func (mr *MockIntCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockIntCmdIFace) SetErr(e error) {
+func (m *MockIntCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockIntCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockIntCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockIntCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockIntCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -1835,15 +1835,15 @@ func (mr *MockIntSliceCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockIntSliceCmdIFace) SetErr(e error) {
+func (m *MockIntSliceCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockIntSliceCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockIntSliceCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockIntSliceCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockIntSliceCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -1984,15 +1984,15 @@ func (mr *MockScanCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockScanCmdIFace) SetErr(e error) {
+func (m *MockScanCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockScanCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockScanCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockScanCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockScanCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -2119,29 +2119,29 @@ func (mr *MockSliceCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // Scan mocks base method.
-func (m *MockSliceCmdIFace) Scan(dst interface{}) error {
+func (m *MockSliceCmdIFace) Scan(arg0 interface{}) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Scan", dst)
+	ret := m.ctrl.Call(m, "Scan", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Scan indicates an expected call of Scan.
-func (mr *MockSliceCmdIFaceMockRecorder) Scan(dst interface{}) *gomock.Call {
+func (mr *MockSliceCmdIFaceMockRecorder) Scan(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockSliceCmdIFace)(nil).Scan), dst)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockSliceCmdIFace)(nil).Scan), arg0)
 }
 
 // SetErr mocks base method.
-func (m *MockSliceCmdIFace) SetErr(e error) {
+func (m *MockSliceCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockSliceCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockSliceCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockSliceCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockSliceCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -2267,15 +2267,15 @@ func (mr *MockSlowLogCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockSlowLogCmdIFace) SetErr(e error) {
+func (m *MockSlowLogCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockSlowLogCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockSlowLogCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockSlowLogCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockSlowLogCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -2401,15 +2401,15 @@ func (mr *MockStatusCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockStatusCmdIFace) SetErr(e error) {
+func (m *MockStatusCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockStatusCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockStatusCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStatusCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStatusCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -2625,29 +2625,29 @@ func (mr *MockStringCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // Scan mocks base method.
-func (m *MockStringCmdIFace) Scan(val interface{}) error {
+func (m *MockStringCmdIFace) Scan(arg0 interface{}) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Scan", val)
+	ret := m.ctrl.Call(m, "Scan", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Scan indicates an expected call of Scan.
-func (mr *MockStringCmdIFaceMockRecorder) Scan(val interface{}) *gomock.Call {
+func (mr *MockStringCmdIFaceMockRecorder) Scan(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockStringCmdIFace)(nil).Scan), val)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockStringCmdIFace)(nil).Scan), arg0)
 }
 
 // SetErr mocks base method.
-func (m *MockStringCmdIFace) SetErr(e error) {
+func (m *MockStringCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockStringCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockStringCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -2803,15 +2803,15 @@ func (mr *MockStringIntMapCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockStringIntMapCmdIFace) SetErr(e error) {
+func (m *MockStringIntMapCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockStringIntMapCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockStringIntMapCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringIntMapCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringIntMapCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -2937,29 +2937,29 @@ func (mr *MockStringSliceCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // ScanSlice mocks base method.
-func (m *MockStringSliceCmdIFace) ScanSlice(container interface{}) error {
+func (m *MockStringSliceCmdIFace) ScanSlice(arg0 interface{}) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ScanSlice", container)
+	ret := m.ctrl.Call(m, "ScanSlice", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ScanSlice indicates an expected call of ScanSlice.
-func (mr *MockStringSliceCmdIFaceMockRecorder) ScanSlice(container interface{}) *gomock.Call {
+func (mr *MockStringSliceCmdIFaceMockRecorder) ScanSlice(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanSlice", reflect.TypeOf((*MockStringSliceCmdIFace)(nil).ScanSlice), container)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanSlice", reflect.TypeOf((*MockStringSliceCmdIFace)(nil).ScanSlice), arg0)
 }
 
 // SetErr mocks base method.
-func (m *MockStringSliceCmdIFace) SetErr(e error) {
+func (m *MockStringSliceCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockStringSliceCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockStringSliceCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringSliceCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringSliceCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -3085,29 +3085,29 @@ func (mr *MockStringStringMapCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // Scan mocks base method.
-func (m *MockStringStringMapCmdIFace) Scan(dst interface{}) error {
+func (m *MockStringStringMapCmdIFace) Scan(arg0 interface{}) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Scan", dst)
+	ret := m.ctrl.Call(m, "Scan", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Scan indicates an expected call of Scan.
-func (mr *MockStringStringMapCmdIFaceMockRecorder) Scan(dst interface{}) *gomock.Call {
+func (mr *MockStringStringMapCmdIFaceMockRecorder) Scan(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockStringStringMapCmdIFace)(nil).Scan), dst)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockStringStringMapCmdIFace)(nil).Scan), arg0)
 }
 
 // SetErr mocks base method.
-func (m *MockStringStringMapCmdIFace) SetErr(e error) {
+func (m *MockStringStringMapCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockStringStringMapCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockStringStringMapCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringStringMapCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringStringMapCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -3233,15 +3233,15 @@ func (mr *MockStringStructMapCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockStringStructMapCmdIFace) SetErr(e error) {
+func (m *MockStringStructMapCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockStringStructMapCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockStringStructMapCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringStructMapCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockStringStructMapCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -3367,15 +3367,15 @@ func (mr *MockTimeCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockTimeCmdIFace) SetErr(e error) {
+func (m *MockTimeCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockTimeCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockTimeCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockTimeCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockTimeCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -3502,15 +3502,15 @@ func (mr *MockXAutoClaimCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXAutoClaimCmdIFace) SetErr(e error) {
+func (m *MockXAutoClaimCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXAutoClaimCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXAutoClaimCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXAutoClaimCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXAutoClaimCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -3638,15 +3638,15 @@ func (mr *MockXAutoClaimJustIDCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXAutoClaimJustIDCmdIFace) SetErr(e error) {
+func (m *MockXAutoClaimJustIDCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXAutoClaimJustIDCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXAutoClaimJustIDCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXAutoClaimJustIDCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXAutoClaimJustIDCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -3773,15 +3773,15 @@ func (mr *MockXInfoConsumersCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXInfoConsumersCmdIFace) SetErr(e error) {
+func (m *MockXInfoConsumersCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXInfoConsumersCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXInfoConsumersCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXInfoConsumersCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXInfoConsumersCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -3907,15 +3907,15 @@ func (mr *MockXInfoGroupsCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXInfoGroupsCmdIFace) SetErr(e error) {
+func (m *MockXInfoGroupsCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXInfoGroupsCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXInfoGroupsCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXInfoGroupsCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXInfoGroupsCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -4041,15 +4041,15 @@ func (mr *MockXInfoStreamCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXInfoStreamCmdIFace) SetErr(e error) {
+func (m *MockXInfoStreamCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXInfoStreamCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXInfoStreamCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXInfoStreamCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXInfoStreamCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -4175,15 +4175,15 @@ func (mr *MockXInfoStreamFullCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXInfoStreamFullCmdIFace) SetErr(e error) {
+func (m *MockXInfoStreamFullCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXInfoStreamFullCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXInfoStreamFullCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXInfoStreamFullCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXInfoStreamFullCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -4309,15 +4309,15 @@ func (mr *MockXMessageSliceCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXMessageSliceCmdIFace) SetErr(e error) {
+func (m *MockXMessageSliceCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXMessageSliceCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXMessageSliceCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXMessageSliceCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXMessageSliceCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -4443,15 +4443,15 @@ func (mr *MockXPendingCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXPendingCmdIFace) SetErr(e error) {
+func (m *MockXPendingCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXPendingCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXPendingCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXPendingCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXPendingCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -4577,15 +4577,15 @@ func (mr *MockXPendingExtCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXPendingExtCmdIFace) SetErr(e error) {
+func (m *MockXPendingExtCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXPendingExtCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXPendingExtCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXPendingExtCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXPendingExtCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -4711,15 +4711,15 @@ func (mr *MockXStreamSliceCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockXStreamSliceCmdIFace) SetErr(e error) {
+func (m *MockXStreamSliceCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockXStreamSliceCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockXStreamSliceCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXStreamSliceCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockXStreamSliceCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -4845,15 +4845,15 @@ func (mr *MockZSliceCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockZSliceCmdIFace) SetErr(e error) {
+func (m *MockZSliceCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockZSliceCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockZSliceCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockZSliceCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockZSliceCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -4979,15 +4979,15 @@ func (mr *MockZWithKeyCmdIFaceMockRecorder) Result() *gomock.Call {
 }
 
 // SetErr mocks base method.
-func (m *MockZWithKeyCmdIFace) SetErr(e error) {
+func (m *MockZWithKeyCmdIFace) SetErr(arg0 error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
+	m.ctrl.Call(m, "SetErr", arg0)
 }
 
 // SetErr indicates an expected call of SetErr.
-func (mr *MockZWithKeyCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
+func (mr *MockZWithKeyCmdIFaceMockRecorder) SetErr(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockZWithKeyCmdIFace)(nil).SetErr), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockZWithKeyCmdIFace)(nil).SetErr), arg0)
 }
 
 // String mocks base method.
@@ -5017,94 +5017,3 @@ func (mr *MockZWithKeyCmdIFaceMockRecorder) Val() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Val", reflect.TypeOf((*MockZWithKeyCmdIFace)(nil).Val))
 }
-
-// MockbaseCmdIFace is a mock of baseCmdIFace interface.
-type MockbaseCmdIFace struct {
-	ctrl     *gomock.Controller
-	recorder *MockbaseCmdIFaceMockRecorder
-}
-
-// MockbaseCmdIFaceMockRecorder is the mock recorder for MockbaseCmdIFace.
-type MockbaseCmdIFaceMockRecorder struct {
-	mock *MockbaseCmdIFace
-}
-
-// NewMockbaseCmdIFace creates a new mock instance.
-func NewMockbaseCmdIFace(ctrl *gomock.Controller) *MockbaseCmdIFace {
-	mock := &MockbaseCmdIFace{ctrl: ctrl}
-	mock.recorder = &MockbaseCmdIFaceMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockbaseCmdIFace) EXPECT() *MockbaseCmdIFaceMockRecorder {
-	return m.recorder
-}
-
-// Args mocks base method.
-func (m *MockbaseCmdIFace) Args() []interface{} {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Args")
-	ret0, _ := ret[0].([]interface{})
-	return ret0
-}
-
-// Args indicates an expected call of Args.
-func (mr *MockbaseCmdIFaceMockRecorder) Args() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Args", reflect.TypeOf((*MockbaseCmdIFace)(nil).Args))
-}
-
-// Err mocks base method.
-func (m *MockbaseCmdIFace) Err() error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Err")
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Err indicates an expected call of Err.
-func (mr *MockbaseCmdIFaceMockRecorder) Err() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockbaseCmdIFace)(nil).Err))
-}
-
-// FullName mocks base method.
-func (m *MockbaseCmdIFace) FullName() string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FullName")
-	ret0, _ := ret[0].(string)
-	return ret0
-}
-
-// FullName indicates an expected call of FullName.
-func (mr *MockbaseCmdIFaceMockRecorder) FullName() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FullName", reflect.TypeOf((*MockbaseCmdIFace)(nil).FullName))
-}
-
-// Name mocks base method.
-func (m *MockbaseCmdIFace) Name() string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Name")
-	ret0, _ := ret[0].(string)
-	return ret0
-}
-
-// Name indicates an expected call of Name.
-func (mr *MockbaseCmdIFaceMockRecorder) Name() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockbaseCmdIFace)(nil).Name))
-}
-
-// SetErr mocks base method.
-func (m *MockbaseCmdIFace) SetErr(e error) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetErr", e)
-}
-
-// SetErr indicates an expected call of SetErr.
-func (mr *MockbaseCmdIFaceMockRecorder) SetErr(e interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErr", reflect.TypeOf((*MockbaseCmdIFace)(nil).SetErr), e)
-}
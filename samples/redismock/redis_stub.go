@@ -1,17 +1,16 @@
 // Code generated by "gogen imake"; DO NOT EDIT.
 // Exec: "gogen imake github.com/go-redis/redis/v8 --to redismock -m .*Cmd$ -o samples/redismock/redis.go --mock"
-// Version: 0.0.1
-// mockgen: v1.6.0
+// Version: 0.0.13
 
 package redismock
 
 import (
 	"reflect"
-	"time"
+	time "time"
 
 	"bou.ke/monkey"
 	redis "github.com/go-redis/redis/v8"
-	"github.com/golang/mock/gomock"
+	"go.uber.org/mock/gomock"
 )
 
 // StubBoolCmdMock stub struct BoolCmd
@@ -50,9 +49,8 @@ func StubBoolCmdMock(ctl *gomock.Controller) (mock *MockBoolCmdIFace, st *redis.
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.BoolCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.BoolCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -67,7 +65,6 @@ func StubBoolCmdMock(ctl *gomock.Controller) (mock *MockBoolCmdIFace, st *redis.
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -107,9 +104,8 @@ func StubBoolSliceCmdMock(ctl *gomock.Controller) (mock *MockBoolSliceCmdIFace,
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.BoolSliceCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.BoolSliceCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -124,7 +120,6 @@ func StubBoolSliceCmdMock(ctl *gomock.Controller) (mock *MockBoolSliceCmdIFace,
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -164,9 +159,8 @@ func StubClusterSlotsCmdMock(ctl *gomock.Controller) (mock *MockClusterSlotsCmdI
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.ClusterSlotsCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.ClusterSlotsCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -181,7 +175,6 @@ func StubClusterSlotsCmdMock(ctl *gomock.Controller) (mock *MockClusterSlotsCmdI
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -251,9 +244,8 @@ func StubCmdMock(ctl *gomock.Controller) (mock *MockCmdIFace, st *redis.Cmd) {
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.Cmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.Cmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -280,7 +272,6 @@ func StubCmdMock(ctl *gomock.Controller) (mock *MockCmdIFace, st *redis.Cmd) {
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -320,9 +311,8 @@ func StubCommandsInfoCmdMock(ctl *gomock.Controller) (mock *MockCommandsInfoCmdI
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.CommandsInfoCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.CommandsInfoCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -337,7 +327,6 @@ func StubCommandsInfoCmdMock(ctl *gomock.Controller) (mock *MockCommandsInfoCmdI
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -377,9 +366,8 @@ func StubDurationCmdMock(ctl *gomock.Controller) (mock *MockDurationCmdIFace, st
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.DurationCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.DurationCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -394,7 +382,6 @@ func StubDurationCmdMock(ctl *gomock.Controller) (mock *MockDurationCmdIFace, st
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -434,9 +421,8 @@ func StubFloatCmdMock(ctl *gomock.Controller) (mock *MockFloatCmdIFace, st *redi
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.FloatCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.FloatCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -451,7 +437,6 @@ func StubFloatCmdMock(ctl *gomock.Controller) (mock *MockFloatCmdIFace, st *redi
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -491,9 +476,8 @@ func StubFloatSliceCmdMock(ctl *gomock.Controller) (mock *MockFloatSliceCmdIFace
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.FloatSliceCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.FloatSliceCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -508,7 +492,6 @@ func StubFloatSliceCmdMock(ctl *gomock.Controller) (mock *MockFloatSliceCmdIFace
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -548,9 +531,8 @@ func StubGeoLocationCmdMock(ctl *gomock.Controller) (mock *MockGeoLocationCmdIFa
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.GeoLocationCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.GeoLocationCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -565,7 +547,6 @@ func StubGeoLocationCmdMock(ctl *gomock.Controller) (mock *MockGeoLocationCmdIFa
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -605,9 +586,8 @@ func StubGeoPosCmdMock(ctl *gomock.Controller) (mock *MockGeoPosCmdIFace, st *re
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.GeoPosCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.GeoPosCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -622,7 +602,6 @@ func StubGeoPosCmdMock(ctl *gomock.Controller) (mock *MockGeoPosCmdIFace, st *re
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -662,9 +641,8 @@ func StubGeoSearchLocationCmdMock(ctl *gomock.Controller) (mock *MockGeoSearchLo
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.GeoSearchLocationCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.GeoSearchLocationCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -679,7 +657,6 @@ func StubGeoSearchLocationCmdMock(ctl *gomock.Controller) (mock *MockGeoSearchLo
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -719,9 +696,8 @@ func StubIntCmdMock(ctl *gomock.Controller) (mock *MockIntCmdIFace, st *redis.In
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.IntCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.IntCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -742,7 +718,6 @@ func StubIntCmdMock(ctl *gomock.Controller) (mock *MockIntCmdIFace, st *redis.In
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -782,9 +757,8 @@ func StubIntSliceCmdMock(ctl *gomock.Controller) (mock *MockIntSliceCmdIFace, st
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.IntSliceCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.IntSliceCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -799,7 +773,6 @@ func StubIntSliceCmdMock(ctl *gomock.Controller) (mock *MockIntSliceCmdIFace, st
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -845,9 +818,8 @@ func StubScanCmdMock(ctl *gomock.Controller) (mock *MockScanCmdIFace, st *redis.
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.ScanCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.ScanCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -862,7 +834,6 @@ func StubScanCmdMock(ctl *gomock.Controller) (mock *MockScanCmdIFace, st *redis.
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -902,15 +873,14 @@ func StubSliceCmdMock(ctl *gomock.Controller) (mock *MockSliceCmdIFace, st *redi
 	)
 	// stub Scan
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "Scan",
-		func(_ *redis.SliceCmd, dst interface{}) error {
-			return mock.Scan(dst)
+		func(_ *redis.SliceCmd, arg0 interface{}) error {
+			return mock.Scan(arg0)
 		},
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.SliceCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.SliceCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -925,7 +895,6 @@ func StubSliceCmdMock(ctl *gomock.Controller) (mock *MockSliceCmdIFace, st *redi
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -965,9 +934,8 @@ func StubSlowLogCmdMock(ctl *gomock.Controller) (mock *MockSlowLogCmdIFace, st *
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.SlowLogCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.SlowLogCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -982,7 +950,6 @@ func StubSlowLogCmdMock(ctl *gomock.Controller) (mock *MockSlowLogCmdIFace, st *
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1022,9 +989,8 @@ func StubStatusCmdMock(ctl *gomock.Controller) (mock *MockStatusCmdIFace, st *re
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.StatusCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.StatusCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1039,7 +1005,6 @@ func StubStatusCmdMock(ctl *gomock.Controller) (mock *MockStatusCmdIFace, st *re
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1115,15 +1080,14 @@ func StubStringCmdMock(ctl *gomock.Controller) (mock *MockStringCmdIFace, st *re
 	)
 	// stub Scan
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "Scan",
-		func(_ *redis.StringCmd, val interface{}) error {
-			return mock.Scan(val)
+		func(_ *redis.StringCmd, arg0 interface{}) error {
+			return mock.Scan(arg0)
 		},
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.StringCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.StringCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1150,7 +1114,6 @@ func StubStringCmdMock(ctl *gomock.Controller) (mock *MockStringCmdIFace, st *re
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1190,9 +1153,8 @@ func StubStringIntMapCmdMock(ctl *gomock.Controller) (mock *MockStringIntMapCmdI
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.StringIntMapCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.StringIntMapCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1207,7 +1169,6 @@ func StubStringIntMapCmdMock(ctl *gomock.Controller) (mock *MockStringIntMapCmdI
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1247,15 +1208,14 @@ func StubStringSliceCmdMock(ctl *gomock.Controller) (mock *MockStringSliceCmdIFa
 	)
 	// stub ScanSlice
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "ScanSlice",
-		func(_ *redis.StringSliceCmd, container interface{}) error {
-			return mock.ScanSlice(container)
+		func(_ *redis.StringSliceCmd, arg0 interface{}) error {
+			return mock.ScanSlice(arg0)
 		},
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.StringSliceCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.StringSliceCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1270,7 +1230,6 @@ func StubStringSliceCmdMock(ctl *gomock.Controller) (mock *MockStringSliceCmdIFa
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1310,15 +1269,14 @@ func StubStringStringMapCmdMock(ctl *gomock.Controller) (mock *MockStringStringM
 	)
 	// stub Scan
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "Scan",
-		func(_ *redis.StringStringMapCmd, dst interface{}) error {
-			return mock.Scan(dst)
+		func(_ *redis.StringStringMapCmd, arg0 interface{}) error {
+			return mock.Scan(arg0)
 		},
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.StringStringMapCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.StringStringMapCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1333,7 +1291,6 @@ func StubStringStringMapCmdMock(ctl *gomock.Controller) (mock *MockStringStringM
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1373,9 +1330,8 @@ func StubStringStructMapCmdMock(ctl *gomock.Controller) (mock *MockStringStructM
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.StringStructMapCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.StringStructMapCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1390,7 +1346,6 @@ func StubStringStructMapCmdMock(ctl *gomock.Controller) (mock *MockStringStructM
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1430,9 +1385,8 @@ func StubTimeCmdMock(ctl *gomock.Controller) (mock *MockTimeCmdIFace, st *redis.
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.TimeCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.TimeCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1447,7 +1401,6 @@ func StubTimeCmdMock(ctl *gomock.Controller) (mock *MockTimeCmdIFace, st *redis.
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1487,9 +1440,8 @@ func StubXAutoClaimCmdMock(ctl *gomock.Controller) (mock *MockXAutoClaimCmdIFace
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XAutoClaimCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XAutoClaimCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1504,7 +1456,6 @@ func StubXAutoClaimCmdMock(ctl *gomock.Controller) (mock *MockXAutoClaimCmdIFace
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1544,9 +1495,8 @@ func StubXAutoClaimJustIDCmdMock(ctl *gomock.Controller) (mock *MockXAutoClaimJu
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XAutoClaimJustIDCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XAutoClaimJustIDCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1561,7 +1511,6 @@ func StubXAutoClaimJustIDCmdMock(ctl *gomock.Controller) (mock *MockXAutoClaimJu
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1601,9 +1550,8 @@ func StubXInfoConsumersCmdMock(ctl *gomock.Controller) (mock *MockXInfoConsumers
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XInfoConsumersCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XInfoConsumersCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1618,7 +1566,6 @@ func StubXInfoConsumersCmdMock(ctl *gomock.Controller) (mock *MockXInfoConsumers
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1658,9 +1605,8 @@ func StubXInfoGroupsCmdMock(ctl *gomock.Controller) (mock *MockXInfoGroupsCmdIFa
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XInfoGroupsCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XInfoGroupsCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1675,7 +1621,6 @@ func StubXInfoGroupsCmdMock(ctl *gomock.Controller) (mock *MockXInfoGroupsCmdIFa
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1715,9 +1660,8 @@ func StubXInfoStreamCmdMock(ctl *gomock.Controller) (mock *MockXInfoStreamCmdIFa
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XInfoStreamCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XInfoStreamCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1732,7 +1676,6 @@ func StubXInfoStreamCmdMock(ctl *gomock.Controller) (mock *MockXInfoStreamCmdIFa
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1772,9 +1715,8 @@ func StubXInfoStreamFullCmdMock(ctl *gomock.Controller) (mock *MockXInfoStreamFu
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XInfoStreamFullCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XInfoStreamFullCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1789,7 +1731,6 @@ func StubXInfoStreamFullCmdMock(ctl *gomock.Controller) (mock *MockXInfoStreamFu
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1829,9 +1770,8 @@ func StubXMessageSliceCmdMock(ctl *gomock.Controller) (mock *MockXMessageSliceCm
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XMessageSliceCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XMessageSliceCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1846,7 +1786,6 @@ func StubXMessageSliceCmdMock(ctl *gomock.Controller) (mock *MockXMessageSliceCm
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1886,9 +1825,8 @@ func StubXPendingCmdMock(ctl *gomock.Controller) (mock *MockXPendingCmdIFace, st
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XPendingCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XPendingCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1903,7 +1841,6 @@ func StubXPendingCmdMock(ctl *gomock.Controller) (mock *MockXPendingCmdIFace, st
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -1943,9 +1880,8 @@ func StubXPendingExtCmdMock(ctl *gomock.Controller) (mock *MockXPendingExtCmdIFa
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XPendingExtCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XPendingExtCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -1960,7 +1896,6 @@ func StubXPendingExtCmdMock(ctl *gomock.Controller) (mock *MockXPendingExtCmdIFa
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -2000,9 +1935,8 @@ func StubXStreamSliceCmdMock(ctl *gomock.Controller) (mock *MockXStreamSliceCmdI
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.XStreamSliceCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.XStreamSliceCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -2017,7 +1951,6 @@ func StubXStreamSliceCmdMock(ctl *gomock.Controller) (mock *MockXStreamSliceCmdI
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -2057,9 +1990,8 @@ func StubZSliceCmdMock(ctl *gomock.Controller) (mock *MockZSliceCmdIFace, st *re
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.ZSliceCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.ZSliceCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -2074,7 +2006,6 @@ func StubZSliceCmdMock(ctl *gomock.Controller) (mock *MockZSliceCmdIFace, st *re
 			return mock.Val()
 		},
 	)
-
 	return
 }
 
@@ -2114,9 +2045,8 @@ func StubZWithKeyCmdMock(ctl *gomock.Controller) (mock *MockZWithKeyCmdIFace, st
 	)
 	// stub SetErr
 	monkey.PatchInstanceMethod(reflect.TypeOf(st), "SetErr",
-		func(_ *redis.ZWithKeyCmd, e error) {
-			mock.SetErr(e)
-			return
+		func(_ *redis.ZWithKeyCmd, arg0 error) {
+			mock.SetErr(arg0)
 		},
 	)
 	// stub String
@@ -2131,6 +2061,5 @@ func StubZWithKeyCmdMock(ctl *gomock.Controller) (mock *MockZWithKeyCmdIFace, st
 			return mock.Val()
 		},
 	)
-
 	return
 }